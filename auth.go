@@ -2,14 +2,40 @@ package arangolite
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/solher/arangolite/v2/requests"
 )
 
+// authTimeout is the maximum duration of an authentication request run on behalf of several requests.
+const authTimeout = 30 * time.Second
+
 type authentication interface {
 	Setup(ctx context.Context, db *Database) error
-	Apply(req *http.Request) error
+	// Apply authenticates the request, renewing the credentials first if needed.
+	Apply(ctx context.Context, db *Database, req *http.Request) error
+	// Renew renews the credentials used by a request rejected with a 401.
+	// It returns false if the credentials can't be renewed.
+	Renew(ctx context.Context, db *Database, req *http.Request) (bool, error)
+}
+
+type noAuth struct{}
+
+func (a noAuth) Setup(ctx context.Context, db *Database) error {
+	return nil
+}
+
+func (a noAuth) Apply(ctx context.Context, db *Database, req *http.Request) error {
+	return nil
+}
+
+func (a noAuth) Renew(ctx context.Context, db *Database, req *http.Request) (bool, error) {
+	return false, nil
 }
 
 type basicAuth struct {
@@ -20,32 +46,146 @@ func (a *basicAuth) Setup(ctx context.Context, db *Database) error {
 	return nil
 }
 
-func (a *basicAuth) Apply(req *http.Request) error {
+func (a *basicAuth) Apply(ctx context.Context, db *Database, req *http.Request) error {
 	req.SetBasicAuth(a.username, a.password)
 	return nil
 }
 
+func (a *basicAuth) Renew(ctx context.Context, db *Database, req *http.Request) (bool, error) {
+	return false, nil
+}
+
 type jwtAuth struct {
 	username, password string
-	jwt                string
+
+	// The token is shared by the copies of the database, so it is guarded.
+	mu  sync.Mutex
+	jwt string
+	// When the token should be renewed. Zero if the token expiry is unknown.
+	renewAt time.Time
+	// The running renewal, if any.
+	renewal *jwtRenewal
+}
+
+// jwtRenewal is a token renewal shared by all the requests waiting for it.
+type jwtRenewal struct {
+	done chan struct{}
+	jwt  string
+	err  error
 }
 
 func (a *jwtAuth) Setup(ctx context.Context, db *Database) error {
-	res, err := db.Send(ctx, &requests.JWTAuth{Username: a.username, Password: a.password})
-	if err != nil {
-		return err
+	a.mu.Lock()
+	jwt := a.jwt
+	a.mu.Unlock()
+	_, err := a.renew(ctx, db, jwt)
+	return err
+}
+
+func (a *jwtAuth) Apply(ctx context.Context, db *Database, req *http.Request) error {
+	a.mu.Lock()
+	jwt, renewAt := a.jwt, a.renewAt
+	a.mu.Unlock()
+
+	if jwt == "" || (!renewAt.IsZero() && time.Now().After(renewAt)) {
+		renewed, err := a.renew(ctx, db, jwt)
+		// A token about to expire is still used if it could not be renewed.
+		if err != nil && jwt == "" {
+			return err
+		}
+		if err == nil {
+			jwt = renewed
+		}
+	}
+
+	req.Header.Set("Authorization", "bearer "+jwt)
+	return nil
+}
+
+func (a *jwtAuth) Renew(ctx context.Context, db *Database, req *http.Request) (bool, error) {
+	stale := strings.TrimPrefix(req.Header.Get("Authorization"), "bearer ")
+	if _, err := a.renew(ctx, db, stale); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// renew replaces the stale token by a new one, unless it has already been replaced.
+// Concurrent renewals are collapsed into a single authentication request.
+func (a *jwtAuth) renew(ctx context.Context, db *Database, stale string) (string, error) {
+	a.mu.Lock()
+	if a.jwt != stale {
+		jwt := a.jwt
+		a.mu.Unlock()
+		return jwt, nil
+	}
+	r := a.renewal
+	if r == nil {
+		r = &jwtRenewal{done: make(chan struct{})}
+		a.renewal = r
+		// The renewal is shared, so it must not be cancelled with the context of the first request.
+		go a.login(db, r)
 	}
+	a.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.jwt, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// login fetches a new token and stores it.
+func (a *jwtAuth) login(db *Database, r *jwtRenewal) {
+	ctx, cancel := context.WithTimeout(context.Background(), authTimeout)
+	defer cancel()
+
+	// The authentication request itself is sent without credentials nor extra headers.
+	// The token is valid for the whole server, so it is fetched from the _system database,
+	// which exists even when the database of the handle doesn't yet.
+	loginDB := *db
+	loginDB.dbName = "_system"
+	loginDB.auth = noAuth{}
+	loginDB.header = nil
+
 	jwtRes := struct {
 		JWT string `json:"jwt"`
 	}{}
-	if err := res.Unmarshal(&jwtRes); err != nil {
-		return err
+	res, err := loginDB.Send(ctx, &requests.JWTAuth{Username: a.username, Password: a.password})
+	if err == nil {
+		err = res.Unmarshal(&jwtRes)
 	}
-	a.jwt = jwtRes.JWT
-	return nil
+
+	a.mu.Lock()
+	if err == nil {
+		a.jwt = jwtRes.JWT
+		a.renewAt = jwtRenewAt(jwtRes.JWT, time.Now())
+	}
+	a.renewal = nil
+	a.mu.Unlock()
+
+	r.jwt, r.err = jwtRes.JWT, err
+	close(r.done)
 }
 
-func (a *jwtAuth) Apply(req *http.Request) error {
-	req.Header.Set("Authorization", "bearer "+a.jwt)
-	return nil
+// jwtRenewAt returns when the token should be renewed: once 90% of its lifetime has elapsed.
+// It returns a zero time if the token has no readable expiry.
+func jwtRenewAt(jwt string, now time.Time) time.Time {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	claims := struct {
+		Exp float64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	lifetime := time.Unix(int64(claims.Exp), 0).Sub(now)
+	return now.Add(lifetime - lifetime/10)
 }
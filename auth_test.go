@@ -0,0 +1,178 @@
+package arangolite_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// jwtServer mocks a database delivering a new token at each login.
+type jwtServer struct {
+	mu     sync.Mutex
+	logins int
+	// The expiry of the delivered tokens, indexed by login. No expiry if missing.
+	expiries []time.Time
+	// The only token accepted by the database. Any token is accepted if empty.
+	valid string
+	// The tokens used by the database requests.
+	used []string
+	// The paths of the login requests.
+	loginPaths []string
+}
+
+func (s *jwtServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.Contains(r.URL.Path, (&requests.JWTAuth{}).Path()) {
+		// Slow logins let the concurrent renewals pile up.
+		time.Sleep(20 * time.Millisecond)
+		s.loginPaths = append(s.loginPaths, r.URL.Path)
+		var exp time.Time
+		if s.logins < len(s.expiries) {
+			exp = s.expiries[s.logins]
+		}
+		s.logins++
+		fmt.Fprintf(w, `{"jwt":%q}`, testJWT(fmt.Sprintf("t%d", s.logins), exp))
+		return
+	}
+
+	jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "bearer ")
+	s.used = append(s.used, jwt)
+	if s.valid != "" && jwt != s.valid {
+		w.WriteHeader(401)
+		return
+	}
+	w.WriteHeader(200)
+}
+
+// locked runs f while the server is locked.
+func (s *jwtServer) locked(f func(s *jwtServer)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s)
+}
+
+// testJWT returns a token identified by its subject, expiring at exp if not zero.
+func testJWT(sub string, exp time.Time) string {
+	payload := fmt.Sprintf(`{"sub":%q}`, sub)
+	if !exp.IsZero() {
+		payload = fmt.Sprintf(`{"sub":%q,"exp":%d}`, sub, exp.Unix())
+	}
+	return "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+// TestJWTRenewal runs tests on the renewal of the JWT tokens.
+func TestJWTRenewal(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("token rejected with a 401", func(t *testing.T) {
+		client, server := httpMock()
+		defer server.Close()
+		s := &jwtServer{}
+		server.Config.Handler = s
+
+		db := arangolite.NewDatabase(arangolite.OptHTTPClient(client), arangolite.OptJWTAuth("foo", "bar"))
+		if err := db.Connect(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		s.locked(func(s *jwtServer) { s.valid = testJWT("t2", time.Time{}) })
+
+		if err := db.Run(ctx, nil, &requests.CurrentDatabase{}); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.logins != 2 {
+			t.Errorf("unexpected number of logins. Expected 2, got %d", s.logins)
+		}
+	})
+
+	t.Run("token still rejected after renewal", func(t *testing.T) {
+		client, server := httpMock()
+		defer server.Close()
+		s := &jwtServer{valid: "revoked"}
+		server.Config.Handler = s
+
+		db := arangolite.NewDatabase(arangolite.OptHTTPClient(client), arangolite.OptJWTAuth("foo", "bar"))
+		if err := db.Run(ctx, nil, &requests.CurrentDatabase{}); !arangolite.IsErrUnauthorized(err) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		// One login for the first request, and a single retry.
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.logins != 2 || len(s.used) != 2 {
+			t.Errorf("unexpected number of logins and requests. Expected 2 and 2, got %d and %d", s.logins, len(s.used))
+		}
+	})
+
+	t.Run("concurrent renewals", func(t *testing.T) {
+		client, server := httpMock()
+		defer server.Close()
+		s := &jwtServer{}
+		server.Config.Handler = s
+
+		db := arangolite.NewDatabase(arangolite.OptHTTPClient(client), arangolite.OptJWTAuth("foo", "bar"))
+		if err := db.Connect(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		s.locked(func(s *jwtServer) { s.valid = testJWT("t2", time.Time{}) })
+
+		errs := make(chan error, 20)
+		for i := 0; i < 20; i++ {
+			go func() { errs <- db.Database("foobar").Run(ctx, nil, &requests.CurrentDatabase{}) }()
+		}
+		for i := 0; i < 20; i++ {
+			if err := <-errs; err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.logins != 2 {
+			t.Errorf("unexpected number of logins. Expected 2, got %d", s.logins)
+		}
+		// The token is always fetched from the _system database, as the database of the handle may not exist.
+		for _, path := range s.loginPaths {
+			if path != "/_db/_system/_open/auth" {
+				t.Errorf("unexpected login path %s", path)
+			}
+		}
+	})
+
+	t.Run("token about to expire", func(t *testing.T) {
+		client, server := httpMock()
+		defer server.Close()
+		s := &jwtServer{expiries: []time.Time{time.Now().Add(-time.Second), time.Now().Add(time.Hour)}}
+		server.Config.Handler = s
+
+		db := arangolite.NewDatabase(arangolite.OptHTTPClient(client), arangolite.OptJWTAuth("foo", "bar"))
+		if err := db.Connect(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := db.Run(ctx, nil, &requests.CurrentDatabase{}); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		expected := testJWT("t2", s.expiries[1])
+		for _, jwt := range s.used {
+			if jwt != expected {
+				t.Errorf("unexpected token. Expected %s, got %s", expected, jwt)
+			}
+		}
+		if s.logins != 2 {
+			t.Errorf("unexpected number of logins. Expected 2, got %d", s.logins)
+		}
+	})
+}
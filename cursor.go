@@ -0,0 +1,125 @@
+package arangolite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// Cursor iterates over the results of a query one document at a time.
+// The next result page is only fetched from the database when the current one
+// has been entirely read, so the result set never has to fit in memory.
+type Cursor struct {
+	db    *Database
+	id    string
+	count int
+	// hasMore indicates if a next result page is available in the database.
+	hasMore bool
+	extra   *QueryExtra
+	dec     *json.Decoder
+	err     error
+}
+
+// Query runs the AQL query and returns a Cursor over its results.
+func (db *Database) Query(ctx context.Context, q *requests.AQL) (*Cursor, error) {
+	res, err := db.send(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cursor{db: db, id: res.parsed.ID, count: res.parsed.Count}
+	if err := c.load(res); err != nil {
+		if c.hasMore {
+			c.db.deleteCursor(c.id)
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// load prepares the decoding of the given result page.
+func (c *Cursor) load(res *response) error {
+	c.hasMore = res.parsed.hasMore()
+	c.extra = c.extra.merge(res.Extra())
+	c.dec = json.NewDecoder(bytes.NewReader(res.parsed.Result))
+
+	if len(res.parsed.Result) == 0 {
+		return errors.New("the database response has no result")
+	}
+	t, err := c.dec.Token()
+	if err != nil {
+		return withMessage(err, "could not decode the cursor result")
+	}
+	if t != json.Delim('[') {
+		return errors.New("the cursor result is not an array")
+	}
+
+	return nil
+}
+
+// Next decodes the next document of the result into the given object.
+// It returns false when the results are exhausted or when an error occurred,
+// in which case the error is returned by Err.
+func (c *Cursor) Next(ctx context.Context, v interface{}) bool {
+	if c.err != nil || c.dec == nil {
+		return false
+	}
+
+	for !c.dec.More() {
+		if !c.hasMore {
+			c.dec = nil
+			return false
+		}
+		res, err := c.db.send(ctx, &requests.FollowCursor{Cursor: c.id})
+		if err != nil {
+			c.err = withMessage(err, "could not follow the query cursor")
+			c.Close()
+			return false
+		}
+		if err := c.load(res); err != nil {
+			c.err = err
+			c.Close()
+			return false
+		}
+	}
+
+	if err := c.dec.Decode(v); err != nil {
+		c.err = withMessage(err, "cursor result unmarshalling failed")
+		c.Close()
+		return false
+	}
+
+	return true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// Count returns the total number of results.
+// It is only available if the query was run with the count option.
+func (c *Cursor) Count() int {
+	return c.count
+}
+
+// Extra returns the extra information (statistics, warnings, profile)
+// combined from every result page fetched so far, if any.
+func (c *Cursor) Extra() *QueryExtra {
+	return c.extra
+}
+
+// Close stops the iteration. If the results are not exhausted,
+// the cursor is deleted in the database instead of waiting for its TTL to expire.
+func (c *Cursor) Close() error {
+	c.dec = nil
+	if !c.hasMore {
+		return nil
+	}
+	c.hasMore = false
+	return c.db.deleteCursor(c.id)
+}
@@ -0,0 +1,243 @@
+package arangolite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestQuery runs tests on the database Query method and the returned Cursor.
+func TestQuery(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		dbHandler http.HandlerFunc
+		// Expected results
+		testErr        func(err error) bool
+		expectedResult []arangolite.Document
+		expectedCount  int
+	}{
+		{
+			description:    "one page",
+			dbHandler:      handler(200, `{"result": [{"_id":"1234"},{"_id":"5678"}], "hasMore": false, "count": 2}`),
+			testErr:        func(err error) bool { return err == nil },
+			expectedResult: []arangolite.Document{{ID: "1234"}, {ID: "5678"}},
+			expectedCount:  2,
+		},
+		{
+			description: "two pages",
+			dbHandler: cursorHandler(
+				200,
+				[]string{
+					`{"result": [{"_id":"1234"}], "hasMore": true, "id": "foobar"}`,
+					`{"result": [{"_id":"4321"}], "hasMore": false, "id": "foobar"}`,
+				},
+				"foobar",
+			),
+			testErr:        func(err error) bool { return err == nil },
+			expectedResult: []arangolite.Document{{ID: "1234"}, {ID: "4321"}},
+		},
+		{
+			description:    "empty result",
+			dbHandler:      handler(200, `{"result": [], "hasMore": false}`),
+			testErr:        func(err error) bool { return err == nil },
+			expectedResult: []arangolite.Document{},
+		},
+		{
+			description:    "invalid document",
+			dbHandler:      handler(200, `{"result": [{"_id":"1234"}, {"_id":1}], "hasMore": false}`),
+			testErr:        func(err error) bool { return err != nil },
+			expectedResult: []arangolite.Document{{ID: "1234"}},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server.Config.Handler = tc.dbHandler
+			db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+			cursor, err := db.Query(ctx, requests.NewAQL(""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cursor.Close()
+
+			result := []arangolite.Document{}
+			for {
+				doc := arangolite.Document{}
+				if !cursor.Next(ctx, &doc) {
+					break
+				}
+				result = append(result, doc)
+			}
+
+			if ok := tc.testErr(cursor.Err()); !ok {
+				t.Errorf("unexpected error: %s", cursor.Err())
+			}
+			if !reflect.DeepEqual(result, tc.expectedResult) {
+				t.Errorf("unexpected result. Expected %v, got %v", tc.expectedResult, result)
+			}
+			if cursor.Count() != tc.expectedCount {
+				t.Errorf("unexpected count. Expected %d, got %d", tc.expectedCount, cursor.Count())
+			}
+		})
+	}
+}
+
+// TestCursorCleanup runs tests on the deletion of server cursors left open.
+func TestCursorCleanup(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		run func(ctx context.Context, db *arangolite.Database) error
+		// The first result page, if not the default one.
+		firstPage string
+		// Expected results
+		deleted bool
+	}{
+		{
+			description: "cursor closed before exhaustion",
+			run: func(ctx context.Context, db *arangolite.Database) error {
+				cursor, err := db.Query(ctx, requests.NewAQL(""))
+				if err != nil {
+					return err
+				}
+				cursor.Next(ctx, &arangolite.Document{})
+				return cursor.Close()
+			},
+			deleted: true,
+		},
+		{
+			description: "cursor exhausted",
+			run: func(ctx context.Context, db *arangolite.Database) error {
+				cursor, err := db.Query(ctx, requests.NewAQL(""))
+				if err != nil {
+					return err
+				}
+				for cursor.Next(ctx, &arangolite.Document{}) {
+				}
+				return cursor.Close()
+			},
+			deleted: false,
+		},
+		{
+			description: "cursor decoding failure",
+			run: func(ctx context.Context, db *arangolite.Database) error {
+				cursor, err := db.Query(ctx, requests.NewAQL(""))
+				if err != nil {
+					return err
+				}
+				cursor.Next(ctx, &[]string{})
+				return nil
+			},
+			deleted: true,
+		},
+		{
+			description: "first page decoding failure",
+			run: func(ctx context.Context, db *arangolite.Database) error {
+				if _, err := db.Query(ctx, requests.NewAQL("")); err == nil {
+					return errors.New("the invalid first page was accepted")
+				}
+				return nil
+			},
+			firstPage: `{"result": {"_id":"1234"}, "hasMore": true, "id": "foobar"}`,
+			deleted:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				deleted bool
+			)
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case "POST":
+					if tc.firstPage != "" {
+						fmt.Fprintln(w, tc.firstPage)
+						return
+					}
+					fmt.Fprintln(w, `{"result": [{"_id":"1234"}], "hasMore": true, "id": "foobar"}`)
+				case "PUT":
+					fmt.Fprintln(w, `{"result": [{"_id":"4321"}], "hasMore": false, "id": "foobar"}`)
+				case "DELETE":
+					mu.Lock()
+					deleted = strings.HasSuffix(r.URL.Path, "/_api/cursor/foobar")
+					mu.Unlock()
+					w.WriteHeader(202)
+				}
+			})
+
+			ctx := context.Background()
+			db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+			if err := tc.run(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if deleted != tc.deleted {
+				t.Errorf("unexpected cursor deletion. Expected %v, got %v", tc.deleted, deleted)
+			}
+		})
+	}
+}
+
+// TestRunCursorCleanup runs tests on the deletion of the server cursor when Run is cancelled.
+func TestRunCursorCleanup(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deleted := make(chan string, 1)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			fmt.Fprintln(w, `{"result": [{"_id":"1234"}], "hasMore": true, "id": "foobar"}`)
+		case "PUT":
+			// The context is cancelled while the next page is being fetched.
+			cancel()
+			time.Sleep(50 * time.Millisecond)
+			fmt.Fprintln(w, `{"result": [{"_id":"4321"}], "hasMore": false, "id": "foobar"}`)
+		case "DELETE":
+			deleted <- r.URL.Path
+			w.WriteHeader(202)
+		}
+	})
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+	if err := db.Run(ctx, &[]arangolite.Document{}, requests.NewAQL("")); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	select {
+	case path := <-deleted:
+		if !strings.HasSuffix(path, "/_api/cursor/foobar") {
+			t.Errorf("unexpected deletion path: %s", path)
+		}
+	default:
+		t.Error("the cursor was not deleted")
+	}
+}
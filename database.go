@@ -15,6 +15,10 @@ import (
 	"github.com/solher/arangolite/v2/requests"
 )
 
+// cleanupTimeout is the maximum duration of the requests releasing database
+// resources (cursors, transactions) after the caller context ended.
+const cleanupTimeout = 10 * time.Second
+
 // Option sets an option for the database connection.
 type Option func(db *Database)
 
@@ -78,6 +82,14 @@ type Runnable interface {
 	Method() string
 }
 
+// HeaderRunnable is a Runnable sending its own HTTP headers, such as the AQL
+// queries allowed to read from followers.
+type HeaderRunnable interface {
+	Runnable
+	// The headers to add to the request.
+	Header() http.Header
+}
+
 // Response defines the response returned by the execution of a Runnable.
 type Response interface {
 	// The raw response from the database.
@@ -96,13 +108,28 @@ type Response interface {
 	UnmarshalResult(v interface{}) error
 }
 
+// QueryResponse is implemented by the responses returned by Send, and gives
+// access to the query information of a cursor response.
+type QueryResponse interface {
+	Response
+	// The total number of results, if the query was run with the count option.
+	Count() int
+	// Cached indicates if the query result was served from the query cache.
+	Cached() bool
+	// The extra information (statistics, warnings, profile) returned with the query results, if present.
+	Extra() *QueryExtra
+}
+
 // Database represents an access to an ArangoDB database.
+// Its configuration can't be changed once created: use With to get a modified copy.
 type Database struct {
 	endpoint string
 	dbName   string
 	cli      *http.Client
 	sender   sender
 	auth     authentication
+	// Additional headers sent with every request.
+	header http.Header
 }
 
 // NewDatabase returns a new Database object.
@@ -129,7 +156,9 @@ func NewDatabase(opts ...Option) *Database {
 		auth:   &basicAuth{},
 	}
 
-	db.Options(opts...)
+	for _, opt := range opts {
+		opt(db)
+	}
 
 	return db
 }
@@ -145,62 +174,92 @@ func (db *Database) Connect(ctx context.Context) error {
 	return nil
 }
 
-// Options apply options to the database.
-func (db *Database) Options(opts ...Option) {
+// Database returns a handle on another database of the same server. The handle shares
+// the HTTP client, the authentication and the logging of db, so it is cheap to create
+// and safe to use concurrently with db, e.g. to serve a tenant per database.
+func (db *Database) Database(name string) *Database {
+	handle := *db
+	handle.dbName = name
+	return &handle
+}
+
+// With returns a copy of the database with the given options applied.
+// The database itself is never modified, so it can be shared between goroutines.
+// The copy shares the authentication of db, unless an authentication option is given,
+// in which case Connect must be called on the copy if the new authentication needs it.
+func (db *Database) With(opts ...Option) *Database {
+	handle := *db
 	for _, opt := range opts {
-		opt(db)
+		opt(&handle)
 	}
+	return &handle
 }
 
 // Run runs the Runnable, follows the query cursor if any and unmarshal
 // the result in the given object.
 func (db *Database) Run(ctx context.Context, v interface{}, q Runnable) error {
+	_, err := db.RunWithExtra(ctx, v, q)
+	return err
+}
+
+// RunWithExtra works like Run but also returns the extra information
+// (statistics, warnings, profile) combined from every result page, if any.
+func (db *Database) RunWithExtra(ctx context.Context, v interface{}, q Runnable) (*QueryExtra, error) {
 	if q == nil {
-		return nil
+		return nil, nil
 	}
 
-	r, err := db.Send(ctx, q)
+	r, err := db.send(ctx, q)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	result, err := db.followCursor(ctx, r)
+	result, extra, err := db.followCursor(ctx, r)
 	if err != nil {
-		return withMessage(err, "could not follow the query cursor")
+		return nil, withMessage(err, "could not follow the query cursor")
 	}
 	if v == nil || result == nil || len(result) == 0 {
-		return nil
+		return extra, nil
 	}
 	if err := json.Unmarshal(result, v); err != nil {
-		return withMessage(err, "run result unmarshalling failed")
+		return extra, withMessage(err, "run result unmarshalling failed")
 	}
 
-	return nil
+	return extra, nil
 }
 
 // Send runs the Runnable and returns a "raw" Response object.
 func (db *Database) Send(ctx context.Context, q Runnable) (Response, error) {
-	if q == nil {
-		return &response{}, nil
-	}
-
-	req, err := http.NewRequest(
-		q.Method(),
-		fmt.Sprintf("%s/_db/%s%s", db.endpoint, db.dbName, q.Path()),
-		bytes.NewBuffer(q.Generate()),
-	)
-	if err != nil {
-		return nil, withMessage(err, "the http request generation failed")
+	res, err := db.send(ctx, q)
+	if res == nil {
+		return nil, err
 	}
+	return res, err
+}
 
-	if err := db.auth.Apply(req); err != nil {
-		return nil, withMessage(err, "authentication returned an error")
+// send runs the Runnable and returns the concrete response.
+func (db *Database) send(ctx context.Context, q Runnable) (*response, error) {
+	if q == nil {
+		return &response{}, nil
 	}
 
-	res, err := db.sender.Send(ctx, db.cli, req)
+	res, req, err := db.sendOnce(ctx, q)
 	if err != nil {
 		return nil, err
 	}
+	// The credentials may have expired or been revoked since they were obtained,
+	// so they are renewed and the request is retried once.
+	if res.statusCode == http.StatusUnauthorized {
+		renewed, err := db.auth.Renew(ctx, db, req)
+		if err != nil {
+			return nil, err
+		}
+		if renewed {
+			if res, _, err = db.sendOnce(ctx, q); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if res.parsed.Error {
 		err = withMessage(errors.New(res.parsed.ErrorMessage), "the database execution returned an error")
 		err = withErrorNum(err, res.parsed.ErrorNum)
@@ -220,17 +279,51 @@ func (db *Database) Send(ctx context.Context, q Runnable) (Response, error) {
 	return res, nil
 }
 
+// sendOnce builds the authenticated HTTP request of the given runnable and sends it.
+func (db *Database) sendOnce(ctx context.Context, q Runnable) (*response, *http.Request, error) {
+	req, err := http.NewRequest(
+		q.Method(),
+		fmt.Sprintf("%s/_db/%s%s", db.endpoint, db.dbName, q.Path()),
+		bytes.NewBuffer(q.Generate()),
+	)
+	if err != nil {
+		return nil, nil, withMessage(err, "the http request generation failed")
+	}
+
+	for k, v := range db.header {
+		req.Header[k] = v
+	}
+	if hq, ok := q.(HeaderRunnable); ok {
+		for k, v := range hq.Header() {
+			req.Header[k] = v
+		}
+	}
+
+	if err := db.auth.Apply(ctx, db, req); err != nil {
+		return nil, nil, withMessage(err, "authentication returned an error")
+	}
+
+	res, err := db.sender.Send(ctx, db.cli, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, req, nil
+}
+
 // followCursor follows the cursor of the given response and returns
-// all elements of every batch returned by the database.
-func (db *Database) followCursor(ctx context.Context, r Response) ([]byte, error) {
+// all elements of every batch returned by the database, as well as
+// their combined extra information.
+func (db *Database) followCursor(ctx context.Context, r *response) ([]byte, *QueryExtra, error) {
+	extra := r.Extra()
+
 	// If the result only has one page
 	if !r.HasMore() {
 		if len(r.RawResult()) != 0 {
 			// Parsed result is not empty, so return this
-			return r.RawResult(), nil
+			return r.RawResult(), extra, nil
 		}
 		// Return the raw result
-		return r.Raw(), nil
+		return r.Raw(), extra, nil
 	}
 
 	buf := bytes.NewBuffer(r.RawResult()[:len(r.RawResult())-1])
@@ -240,10 +333,15 @@ func (db *Database) followCursor(ctx context.Context, r Response) ([]byte, error
 	var err error
 
 	for r.HasMore() {
-		r, err = db.Send(ctx, q)
+		r, err = db.send(ctx, q)
 		if err != nil {
-			return nil, err
+			if ctx.Err() != nil {
+				// The cursor would otherwise stay open until its TTL expires.
+				db.deleteCursor(q.Cursor)
+			}
+			return nil, nil, err
 		}
+		extra = extra.merge(r.Extra())
 		buf.Write(r.RawResult()[1 : len(r.RawResult())-1])
 		buf.WriteRune(',')
 	}
@@ -251,5 +349,17 @@ func (db *Database) followCursor(ctx context.Context, r Response) ([]byte, error
 	buf.Truncate(buf.Len() - 1)
 	buf.WriteRune(']')
 
-	return buf.Bytes(), nil
+	return buf.Bytes(), extra, nil
+}
+
+// deleteCursor deletes the given server cursor. As the caller context may already
+// be cancelled, the deletion runs in its own context.
+func (db *Database) deleteCursor(cursor string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	if _, err := db.send(ctx, &requests.DeleteCursor{Cursor: cursor}); err != nil && !IsErrNotFound(err) {
+		return withMessage(err, "could not delete the query cursor")
+	}
+	return nil
 }
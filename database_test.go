@@ -147,6 +147,25 @@ func TestOptionsSend(t *testing.T) {
 	}
 }
 
+// TestRunnableHeader checks that the headers of the requests are sent.
+func TestRunnableHeader(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-arango-allow-dirty-read") != "true" {
+			w.WriteHeader(400)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"result": [], "hasMore": false}`)
+	})
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+	if err := db.Run(context.Background(), nil, requests.NewAQL("").AllowDirtyReads(true)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
 // TestRun runs tests on the database Run method.
 func TestRun(t *testing.T) {
 	client, server := httpMock()
@@ -247,6 +266,81 @@ func TestRun(t *testing.T) {
 			testErr:        func(err error) bool { return err == nil },
 			expectedResult: &struct{ ID string }{ID: "node_port_relations/365"},
 		},
+		{
+			description: "database execution requests.CreateDocument",
+			query:       &requests.CreateDocument{Collection: "nodes", Document: &arangolite.Document{}},
+			result:      &arangolite.Document{},
+			dbHandler: handler(
+				200,
+				`{"_id":"nodes/1234","_key":"1234","_rev":"_bSCeZWq---"}`,
+			),
+			testErr:        func(err error) bool { return err == nil },
+			expectedResult: &arangolite.Document{ID: "nodes/1234", Key: "1234", Rev: "_bSCeZWq---"},
+		},
+		{
+			description: "database execution requests.GetRunningQueries",
+			query:       &requests.GetRunningQueries{},
+			result:      &[]requests.RunningQuery{},
+			dbHandler: handlerContentType(
+				200,
+				`[{"id":"35","database":"_system","user":"root","query":"RETURN SLEEP(@d)","bindVars":{"d":10},`+
+					`"started":"2026-10-16T09:00:00Z","runTime":2.5,"state":"executing","stream":false}]`,
+				"application/json; charset=utf-8",
+			),
+			testErr: func(err error) bool { return err == nil },
+			expectedResult: &[]requests.RunningQuery{{
+				ID: "35", Database: "_system", User: "root", Query: "RETURN SLEEP(@d)",
+				BindVars: map[string]interface{}{"d": float64(10)},
+				Started:  "2026-10-16T09:00:00Z", RunTime: 2.5, State: "executing",
+			}},
+		},
+		{
+			description: "database execution requests.CurrentDatabase",
+			query:       &requests.CurrentDatabase{},
+			result:      &requests.DatabaseInfo{},
+			dbHandler: handler(
+				200,
+				`{"error":false,"code":200,"result":{"name":"foobar","id":"123","path":"/data/foobar","isSystem":false,`+
+					`"sharding":"single","replicationFactor":2,"writeConcern":1}}`,
+			),
+			testErr: func(err error) bool { return err == nil },
+			expectedResult: &requests.DatabaseInfo{
+				Name: "foobar", ID: "123", Path: "/data/foobar",
+				Sharding: "single", ReplicationFactor: 2, WriteConcern: 1,
+			},
+		},
+		{
+			description:    "database execution requests.GetDatabaseAccess",
+			query:          &requests.GetDatabaseAccess{User: "foo", Database: requests.AllDatabases},
+			result:         new(requests.AccessLevel),
+			dbHandler:      handler(200, `{"error":false,"code":200,"result":"ro"}`),
+			testErr:        func(err error) bool { return err == nil },
+			expectedResult: func() *requests.AccessLevel { l := requests.AccessReadOnly; return &l }(),
+		},
+		{
+			description: "database execution requests.GetUserDatabases full",
+			query:       &requests.GetUserDatabases{User: "foo", Full: true},
+			result:      &map[string]requests.DatabaseAccess{},
+			dbHandler: handler(
+				200,
+				`{"error":false,"code":200,"result":{"foobar":{"permission":"rw","collections":{"nodes":"ro","*":"rw"}}}}`,
+			),
+			testErr: func(err error) bool { return err == nil },
+			expectedResult: &map[string]requests.DatabaseAccess{
+				"foobar": {
+					Permission:  requests.AccessReadWrite,
+					Collections: map[string]requests.AccessLevel{"nodes": requests.AccessReadOnly, "*": requests.AccessReadWrite},
+				},
+			},
+		},
+		{
+			description:    "database execution requests.DocumentExists not found",
+			query:          &requests.DocumentExists{Collection: "nodes", Key: "1234"},
+			result:         nil,
+			dbHandler:      handlerContentType(404, ``, "application/json"),
+			testErr:        func(err error) bool { return arangolite.IsErrNotFound(err) },
+			expectedResult: nil,
+		},
 	}
 
 	ctx := context.Background()
@@ -266,6 +360,44 @@ func TestRun(t *testing.T) {
 	}
 }
 
+// TestRunWithExtra runs tests on the extra information returned by the database RunWithExtra method.
+func TestRunWithExtra(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+	server.Config.Handler = cursorHandler(
+		200,
+		[]string{
+			`{"result": [{"_id":"1234"}], "hasMore": true, "id": "foobar", "count": 2,
+			  "extra": {"warnings": [{"code": 1562, "message": "division by zero"}]}}`,
+			`{"result": [{"_id":"4321"}], "hasMore": false, "id": "foobar",
+			  "extra": {"stats": {"writesExecuted": 0, "scannedFull": 2, "fullCount": 10, "executionTime": 0.5},
+			            "warnings": [{"code": 1562, "message": "division by zero"}]}}`,
+		},
+		"foobar",
+	)
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+	result := []arangolite.Document{}
+	extra, err := db.RunWithExtra(context.Background(), &result, requests.NewAQL(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedExtra := &arangolite.QueryExtra{
+		Stats: arangolite.QueryStats{ScannedFull: 2, FullCount: 10, ExecutionTime: 0.5},
+		Warnings: []arangolite.QueryWarning{
+			{Code: 1562, Message: "division by zero"},
+			{Code: 1562, Message: "division by zero"},
+		},
+	}
+	if !reflect.DeepEqual(extra, expectedExtra) {
+		t.Errorf("unexpected extra. Expected %+v, got %+v", expectedExtra, extra)
+	}
+	if len(result) != 2 {
+		t.Errorf("unexpected result. Expected 2 documents, got %v", result)
+	}
+}
+
 // TestSend runs tests on the database Send method.
 func TestSend(t *testing.T) {
 	client, server := httpMock()
@@ -284,6 +416,9 @@ func TestSend(t *testing.T) {
 		rawResult json.RawMessage
 		hasMore   bool
 		cursor    string
+		count     int
+		cached    bool
+		extra     *arangolite.QueryExtra
 	}{
 		{
 			description: "database returns a 500",
@@ -334,6 +469,22 @@ func TestSend(t *testing.T) {
 			raw:         json.RawMessage(`{"result": {}, "cached": true}`),
 			rawResult:   json.RawMessage(`{}`),
 			hasMore:     false,
+			cached:      true,
+		},
+		{
+			description: "database execution succeeds with count and extra",
+			dbHandler:   handler(200, `{"result": [], "hasMore": false, "count": 3, "extra": {"stats": {"writesExecuted": 3}}}`),
+			testErr:     func(err error) bool { return err == nil },
+			raw:         json.RawMessage(`{"result": [], "hasMore": false, "count": 3, "extra": {"stats": {"writesExecuted": 3}}}`),
+			rawResult:   json.RawMessage(`[]`),
+			count:       3,
+			extra:       &arangolite.QueryExtra{Stats: arangolite.QueryStats{WritesExecuted: 3}},
+		},
+		{
+			description: "database execution succeeds with a user extra",
+			dbHandler:   handler(200, `{"user": "foo", "active": true, "extra": {"theme": "dark"}}`),
+			testErr:     func(err error) bool { return err == nil },
+			raw:         json.RawMessage(`{"user": "foo", "active": true, "extra": {"theme": "dark"}}`),
 		},
 		{
 			description: "database execution succeeds multiple pages",
@@ -372,6 +523,19 @@ func TestSend(t *testing.T) {
 			if !reflect.DeepEqual(tc.cursor, result.Cursor()) {
 				t.Errorf("unexpected cursor. Expected %v, got %v", tc.cursor, result.Cursor())
 			}
+			queryResult, ok := result.(arangolite.QueryResponse)
+			if !ok {
+				t.Fatalf("the response is not a QueryResponse")
+			}
+			if tc.count != queryResult.Count() {
+				t.Errorf("unexpected count. Expected %v, got %v", tc.count, queryResult.Count())
+			}
+			if tc.cached != queryResult.Cached() {
+				t.Errorf("unexpected cached. Expected %v, got %v", tc.cached, queryResult.Cached())
+			}
+			if !reflect.DeepEqual(tc.extra, queryResult.Extra()) {
+				t.Errorf("unexpected extra. Expected %v, got %v", tc.extra, queryResult.Extra())
+			}
 		})
 	}
 }
@@ -451,3 +615,82 @@ func connectHandler(jwtStatus int, body string) http.HandlerFunc {
 		}
 	})
 }
+
+// TestDatabaseHandle runs tests on the handles returned by the database Database method.
+func TestDatabaseHandle(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The database name is echoed, so each handle can check it targets its own database.
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"result": {"name": %q}}`, strings.Split(r.URL.Path, "/")[2])
+	})
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+	names := []string{"_system", "tenant1", "tenant2", "tenant3"}
+	errs := make(chan error, len(names)*10)
+	for i := 0; i < 10; i++ {
+		for _, name := range names {
+			go func(name string) {
+				handle := db
+				if name != "_system" {
+					handle = db.Database(name)
+				}
+				info := &requests.DatabaseInfo{}
+				err := handle.Run(context.Background(), info, &requests.CurrentDatabase{})
+				if err == nil && info.Name != name {
+					err = fmt.Errorf("unexpected database. Expected %s, got %s", name, info.Name)
+				}
+				errs <- err
+			}(name)
+		}
+	}
+	for i := 0; i < len(names)*10; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// TestWith runs tests on the database With method.
+func TestWith(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+	server.Config.Handler = connectHandler(200, ``)
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client), arangolite.OptBasicAuth("foo", "invalid"))
+	valid := db.With(arangolite.OptBasicAuth("foo", "bar"))
+
+	ctx := context.Background()
+	if err := valid.Connect(ctx); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := db.Connect(ctx); !arangolite.IsErrUnauthorized(err) {
+		t.Errorf("the original database was modified, got error: %v", err)
+	}
+}
+
+// TestConcurrentJWT runs concurrent requests while the JWT token is being set up.
+// It is meant to be run with the race detector.
+func TestConcurrentJWT(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+	server.Config.Handler = connectHandler(200, ``)
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client), arangolite.OptJWTAuth("foo", "bar"))
+	ctx := context.Background()
+	if err := db.Connect(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	done := make(chan error, 20)
+	for i := 0; i < 10; i++ {
+		go func() { done <- db.Connect(ctx) }()
+		go func() { done <- db.Database("foobar").Run(ctx, nil, &requests.CurrentDatabase{}) }()
+	}
+	for i := 0; i < 20; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+}
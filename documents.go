@@ -1,5 +1,10 @@
 package arangolite
 
+import (
+	"encoding/json"
+	"errors"
+)
+
 // Document represents a basic ArangoDB document
 type Document struct {
 	// The document handle. Format: ':collection/:key'
@@ -18,3 +23,57 @@ type Edge struct {
 	// Reference to another document. Format: ':collection/:key'
 	To string `json:"_to,omitempty"`
 }
+
+// DocumentResult is the result of a single document in a batch operation.
+type DocumentResult struct {
+	Document
+	// The new document, if requested with ReturnNew.
+	New json.RawMessage `json:"new,omitempty"`
+	// The previous document, if requested with ReturnOld.
+	Old json.RawMessage `json:"old,omitempty"`
+	// The error returned by the database for this document, if any.
+	// The status code and error num helpers can be used on it.
+	Err error `json:"-"`
+}
+
+// UnmarshalJSON decodes either the document metadata or the database error.
+func (r *DocumentResult) UnmarshalJSON(b []byte) error {
+	parsed := parsedResponse{}
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		return err
+	}
+	if parsed.Error {
+		*r = DocumentResult{}
+		r.Err = withErrorNum(
+			withMessage(errors.New(parsed.ErrorMessage), "the database execution returned an error"),
+			parsed.ErrorNum,
+		)
+		return nil
+	}
+	type documentResult DocumentResult
+	return json.Unmarshal(b, (*documentResult)(r))
+}
+
+// DocumentResults are the results of a batch operation, one per given document and in the same order.
+type DocumentResults []DocumentResult
+
+// Err returns the first error of the batch, if any.
+func (r DocumentResults) Err() error {
+	for _, result := range r {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// Failed returns the indexes of the documents for which the operation failed.
+func (r DocumentResults) Failed() []int {
+	failed := []int{}
+	for i, result := range r {
+		if result.Err != nil {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,105 @@
+package arangolite_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestDocumentResults runs tests on the decoding of batch operation results.
+func TestDocumentResults(t *testing.T) {
+	raw := `[
+		{"_id":"nodes/1","_key":"1","_rev":"_a"},
+		{"error":true,"errorNum":1210,"errorMessage":"unique constraint violated"},
+		{"_id":"nodes/3","_key":"3","_rev":"_c","new":{"_key":"3","foo":"bar"}}
+	]`
+
+	results := arangolite.DocumentResults{}
+	if err := json.Unmarshal([]byte(raw), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("unexpected result count. Expected 3, got %d", len(results))
+	}
+	if results[0].Key != "1" || results[0].Err != nil {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if !arangolite.IsErrUnique(results[1].Err) {
+		t.Errorf("unexpected second result error: %v", results[1].Err)
+	}
+	if results[2].Rev != "_c" || string(results[2].New) != `{"_key":"3","foo":"bar"}` {
+		t.Errorf("unexpected third result: %+v", results[2])
+	}
+	if !arangolite.IsErrUnique(results.Err()) {
+		t.Errorf("unexpected batch error: %v", results.Err())
+	}
+	if failed := results.Failed(); len(failed) != 1 || failed[0] != 1 {
+		t.Errorf("unexpected failed indexes: %v", failed)
+	}
+}
+
+// TestSilentBatch checks that the batch results still match the given documents when Silent is set.
+func TestSilentBatch(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		silentBody string
+		body       string
+		// Expected results
+		failed []int
+	}{
+		{
+			description: "every document succeeds",
+			silentBody:  `{}`,
+			body:        `[{"_key":"1"},{"_key":"2"}]`,
+			failed:      []int{},
+		},
+		{
+			description: "some documents fail",
+			silentBody:  `[{"error":true,"errorNum":1210,"errorMessage":"unique constraint violated"}]`,
+			body:        `[{"_key":"1"},{"error":true,"errorNum":1210,"errorMessage":"unique constraint violated"}]`,
+			failed:      []int{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The database only returns the failed documents, if any, with the silent option.
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(202)
+				if r.URL.Query().Get("silent") == "true" {
+					w.Write([]byte(tc.silentBody))
+					return
+				}
+				w.Write([]byte(tc.body))
+			})
+
+			db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+			results := arangolite.DocumentResults{}
+			q := &requests.CreateDocuments{
+				Collection:      "nodes",
+				Documents:       []arangolite.Document{{Key: "1"}, {Key: "2"}},
+				DocumentOptions: requests.DocumentOptions{Silent: true},
+			}
+			if err := db.Run(context.Background(), &results, q); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(results) != 2 {
+				t.Errorf("unexpected result count. Expected 2, got %d", len(results))
+			}
+			if failed := results.Failed(); !reflect.DeepEqual(failed, tc.failed) {
+				t.Errorf("unexpected failed indexes. Expected %v, got %v", tc.failed, failed)
+			}
+		})
+	}
+}
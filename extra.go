@@ -0,0 +1,69 @@
+package arangolite
+
+import "encoding/json"
+
+// QueryExtra holds the extra information returned by the database with the query results.
+type QueryExtra struct {
+	// The execution statistics of the query.
+	Stats QueryStats `json:"stats"`
+	// The warnings raised during the query execution.
+	Warnings []QueryWarning `json:"warnings,omitempty"`
+	// The duration of each execution phase, in seconds. Only set when the query is profiled.
+	Profile map[string]float64 `json:"profile,omitempty"`
+	// The execution plan, with per-node statistics. Only set when the query is profiled at level 2.
+	Plan json.RawMessage `json:"plan,omitempty"`
+}
+
+// QueryStats holds the execution statistics of a query.
+type QueryStats struct {
+	// The number of successfully executed modification operations.
+	WritesExecuted int64 `json:"writesExecuted"`
+	// The number of failed modification operations that were ignored.
+	WritesIgnored int64 `json:"writesIgnored"`
+	// The number of documents iterated over when scanning collections without an index.
+	ScannedFull int64 `json:"scannedFull"`
+	// The number of documents iterated over when scanning collections with an index.
+	ScannedIndex int64 `json:"scannedIndex"`
+	// The number of documents removed by FILTER conditions.
+	Filtered int64 `json:"filtered"`
+	// The number of cluster-internal HTTP requests.
+	HTTPRequests int64 `json:"httpRequests"`
+	// The number of results without the last top-level LIMIT. Only set when the
+	// query is run with the fullCount option.
+	FullCount int64 `json:"fullCount"`
+	// The query execution time, in seconds.
+	ExecutionTime float64 `json:"executionTime"`
+	// The maximum memory usage of the query, in bytes.
+	PeakMemoryUsage int64 `json:"peakMemoryUsage"`
+}
+
+// QueryWarning is a warning raised during a query execution.
+type QueryWarning struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// merge combines the extra information of a new result page with the current one.
+// Statistics are cumulative, so the most recent ones are kept, while warnings are accumulated.
+func (e *QueryExtra) merge(next *QueryExtra) *QueryExtra {
+	if next == nil {
+		return e
+	}
+	if e == nil {
+		merged := *next
+		return &merged
+	}
+
+	merged := *e
+	if next.Stats != (QueryStats{}) {
+		merged.Stats = next.Stats
+	}
+	merged.Warnings = append(append([]QueryWarning{}, e.Warnings...), next.Warnings...)
+	if next.Profile != nil {
+		merged.Profile = next.Profile
+	}
+	if next.Plan != nil {
+		merged.Plan = next.Plan
+	}
+	return &merged
+}
@@ -0,0 +1,314 @@
+package arangolite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// JSONSchema generates the JSON Schema of the documents represented by the given struct.
+//
+// The attribute names are read from the json tags, and the system attributes
+// (starting with '_') are left to the database. The following validation tags
+// are supported: required, min, max, len and oneof.
+//
+// e.g. Name string `json:"name" validate:"required,min=1,max=64"`
+func JSONSchema(v interface{}) (map[string]interface{}, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("a JSON Schema can only be generated from a struct, got %v", t)
+	}
+	return typeSchema(t, map[reflect.Type]bool{})
+}
+
+// SchemaRule generates the validation rule of the documents represented by the given struct.
+// The level is one of "none", "new", "moderate" or "strict".
+func SchemaRule(v interface{}, level, message string) (*requests.CollectionSchemaRule, error) {
+	rule, err := JSONSchema(v)
+	if err != nil {
+		return nil, err
+	}
+	return &requests.CollectionSchemaRule{Rule: rule, Level: level, Message: message}, nil
+}
+
+// SchemaRuleDrift compares the validation rule of a collection with the given one,
+// and returns the differences. No difference is returned if the rules match.
+func (db *Database) SchemaRuleDrift(ctx context.Context, collection string, rule *requests.CollectionSchemaRule) ([]SchemaDifference, error) {
+	properties := &requests.CollectionProperties{}
+	if err := db.Run(ctx, properties, &requests.GetCollectionProperties{Name: collection}); err != nil {
+		return nil, err
+	}
+
+	obj := SchemaObject{Kind: SchemaCollection, Database: db.dbName, Name: collection}
+	differences := []SchemaDifference{}
+	live := properties.Schema
+	switch {
+	case live == nil && rule == nil:
+	case live == nil:
+		differences = append(differences, SchemaDifference{SchemaObject: obj, Reason: "the collection has no validation rule"})
+	case rule == nil:
+		differences = append(differences, SchemaDifference{SchemaObject: obj, Reason: "the collection has an unexpected validation rule"})
+	default:
+		equal, err := equalJSON(live.Rule, rule.Rule)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			differences = append(differences, SchemaDifference{SchemaObject: obj, Reason: "the validation rule differs"})
+		}
+		if rule.Level != "" && live.Level != rule.Level {
+			differences = append(differences, SchemaDifference{
+				SchemaObject: obj,
+				Reason:       fmt.Sprintf("the validation level is %s instead of %s", live.Level, rule.Level),
+			})
+		}
+		if live.Message != rule.Message {
+			differences = append(differences, SchemaDifference{
+				SchemaObject: obj,
+				Reason:       fmt.Sprintf("the validation message is %q instead of %q", live.Message, rule.Message),
+			})
+		}
+	}
+
+	return differences, nil
+}
+
+// equalJSON compares two values by their JSON representation.
+func equalJSON(a, b interface{}) (bool, error) {
+	var decodedA, decodedB interface{}
+	for _, v := range []struct {
+		in  interface{}
+		out *interface{}
+	}{{a, &decodedA}, {b, &decodedB}} {
+		m, err := json.Marshal(v.in)
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(m, v.out); err != nil {
+			return false, err
+		}
+	}
+	return reflect.DeepEqual(decodedA, decodedB), nil
+}
+
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]interface{}, error) {
+	if t.Kind() != reflect.Ptr {
+		switch {
+		case t == timeType:
+			return map[string]interface{}{"type": "string"}, nil
+		// Types implementing their own encoding can't be described.
+		case t.Implements(jsonMarshaler) || reflect.PtrTo(t).Implements(jsonMarshaler):
+			return map[string]interface{}{}, nil
+		case t.Implements(textMarshaler) || reflect.PtrTo(t).Implements(textMarshaler):
+			return map[string]interface{}{"type": "string"}, nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema, err := typeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(schema), nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		var schema map[string]interface{}
+		// Byte slices are encoded as base64 strings.
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			schema = map[string]interface{}{"type": "string"}
+		} else {
+			items, err := typeSchema(t.Elem(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			schema = map[string]interface{}{"type": "array", "items": items}
+		}
+		// Nil slices are encoded as null.
+		if t.Kind() == reflect.Slice {
+			return nullable(schema), nil
+		}
+		return schema, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %v", t.Key())
+		}
+		values, err := typeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		// Nil maps are encoded as null.
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": values}), nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	}
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+// nullable allows the null value in a schema.
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if jsonType, ok := schema["type"].(string); ok {
+		schema["type"] = []string{jsonType, "null"}
+	}
+	return schema
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]interface{}, error) {
+	// Recursive types are only described once.
+	if visiting[t] {
+		return map[string]interface{}{"type": "object"}, nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	properties := map[string]interface{}{}
+	required := []string{}
+	if err := addFields(t, visiting, properties, &required); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// addFields adds the schema of the struct fields to the properties, flattening the embedded structs.
+func addFields(t reflect.Type, visiting map[reflect.Type]bool, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if err := addFields(fieldType, visiting, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+
+		schema, err := typeSchema(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %s", field.Name, err.Error())
+		}
+		isRequired, err := applyValidation(schema, field.Type, field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("field %s: %s", field.Name, err.Error())
+		}
+		if isRequired {
+			*required = append(*required, name)
+			// A required slice or map must be set, so it can't be null.
+			if kind := field.Type.Kind(); kind == reflect.Slice || kind == reflect.Map {
+				if types, ok := schema["type"].([]string); ok {
+					schema["type"] = types[0]
+				}
+			}
+		}
+		properties[name] = schema
+	}
+	return nil
+}
+
+// applyValidation adds the constraints of a validate tag to a schema, and returns if the field is required.
+func applyValidation(schema map[string]interface{}, t reflect.Type, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var minKey, maxKey string
+	switch t.Kind() {
+	case reflect.String:
+		minKey, maxKey = "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		minKey, maxKey = "minItems", "maxItems"
+	case reflect.Map:
+		minKey, maxKey = "minProperties", "maxProperties"
+	default:
+		minKey, maxKey = "minimum", "maximum"
+	}
+
+	isRequired := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, value := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, value = rule[:i], rule[i+1:]
+		}
+
+		switch key {
+		case "required":
+			isRequired = true
+		case "min", "max", "len":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s validation value %q", key, value)
+			}
+			if key != "max" {
+				schema[minKey] = n
+			}
+			if key != "min" {
+				schema[maxKey] = n
+			}
+		case "oneof":
+			enum := []interface{}{}
+			for _, v := range strings.Fields(value) {
+				if t.Kind() == reflect.String {
+					enum = append(enum, v)
+					continue
+				}
+				n, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return false, fmt.Errorf("invalid oneof validation value %q", v)
+				}
+				enum = append(enum, n)
+			}
+			schema["enum"] = enum
+		}
+	}
+
+	return isRequired, nil
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*interface{ MarshalText() ([]byte, error) })(nil)).Elem()
+)
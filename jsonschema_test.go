@@ -0,0 +1,205 @@
+package arangolite_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/solher/arangolite/v2"
+)
+
+type schemaAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type schemaNode struct {
+	arangolite.Document
+	Name      string            `json:"name" validate:"required,min=1,max=64"`
+	Kind      string            `json:"kind,omitempty" validate:"oneof=server router"`
+	Ports     []int             `json:"ports" validate:"max=8"`
+	Weight    float64           `json:"weight" validate:"min=0"`
+	Address   *schemaAddress    `json:"address,omitempty"`
+	Labels    map[string]string `json:"labels"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Internal  string            `json:"-"`
+	secret    string
+}
+
+// TestJSONSchema runs tests on the JSON Schema generation.
+func TestJSONSchema(t *testing.T) {
+	schema, err := arangolite.JSONSchema(&schemaNode{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1, "maxLength": 64},
+			"kind": {"type": "string", "enum": ["server", "router"]},
+			"ports": {"type": ["array", "null"], "items": {"type": "integer"}, "maxItems": 8},
+			"weight": {"type": "number", "minimum": 0},
+			"address": {"type": ["object", "null"], "required": ["city"], "properties": {"city": {"type": "string"}}},
+			"labels": {"type": ["object", "null"], "additionalProperties": {"type": "string"}},
+			"createdAt": {"type": "string"}
+		}
+	}`
+	if equal, err := equalJSON(schema, expected); err != nil || !equal {
+		m, _ := json.Marshal(schema)
+		t.Errorf("unexpected schema. Expected %s, got %s", expected, m)
+	}
+
+	// The zero value of a struct must be valid against its own schema.
+	m, _ := json.Marshal(schemaNode{})
+	document := map[string]interface{}{}
+	json.Unmarshal(m, &document)
+	if err := checkTypes(document, schema); err != nil {
+		t.Errorf("the zero value does not match its schema: %s", err)
+	}
+
+	required, err := arangolite.JSONSchema(struct {
+		Tags []string `json:"tags" validate:"required"`
+	}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = `{
+		"type": "object",
+		"required": ["tags"],
+		"properties": {"tags": {"type": "array", "items": {"type": "string"}}}
+	}`
+	if equal, err := equalJSON(required, expected); err != nil || !equal {
+		m, _ := json.Marshal(required)
+		t.Errorf("unexpected schema. Expected %s, got %s", expected, m)
+	}
+
+	if _, err := arangolite.JSONSchema("foobar"); err == nil {
+		t.Errorf("expected an error for a non struct value")
+	}
+}
+
+// checkTypes checks the JSON types of a decoded value against the type constraints of a schema.
+func checkTypes(v interface{}, schema map[string]interface{}) error {
+	jsonType := "null"
+	switch v.(type) {
+	case bool:
+		jsonType = "boolean"
+	case float64:
+		jsonType = "number"
+	case string:
+		jsonType = "string"
+	case []interface{}:
+		jsonType = "array"
+	case map[string]interface{}:
+		jsonType = "object"
+	}
+
+	allowed := []string{}
+	switch t := schema["type"].(type) {
+	case string:
+		allowed = append(allowed, t)
+	case []string:
+		allowed = append(allowed, t...)
+	}
+	matches := len(allowed) == 0
+	for _, a := range allowed {
+		matches = matches || a == jsonType || (a == "integer" && jsonType == "number")
+	}
+	if !matches {
+		return fmt.Errorf("%v is not of type %v", v, allowed)
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, value := range v {
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				if err := checkTypes(value, property); err != nil {
+					return fmt.Errorf("%s: %s", name, err)
+				}
+			}
+		}
+	case []interface{}:
+		items, _ := schema["items"].(map[string]interface{})
+		for _, value := range v {
+			if err := checkTypes(value, items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TestSchemaRuleDrift runs tests on the database SchemaRuleDrift method.
+func TestSchemaRuleDrift(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	rule, err := arangolite.SchemaRule(schemaAddress{}, "strict", "invalid address")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		dbHandler http.HandlerFunc
+		// Expected results
+		differences int
+	}{
+		{
+			description: "same rule",
+			dbHandler: handler(200, `{"name": "addresses", "schema": {
+				"rule": {"properties": {"city": {"type": "string"}}, "required": ["city"], "type": "object"},
+				"level": "strict", "message": "invalid address", "type": "json"}}`),
+			differences: 0,
+		},
+		{
+			description: "drifted rule and level",
+			dbHandler: handler(200, `{"name": "addresses", "schema": {
+				"rule": {"properties": {"city": {"type": "string"}}, "type": "object"},
+				"level": "moderate", "message": "invalid address", "type": "json"}}`),
+			differences: 2,
+		},
+		{
+			description: "missing rule",
+			dbHandler:   handler(200, `{"name": "addresses", "schema": null}`),
+			differences: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server.Config.Handler = tc.dbHandler
+			db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+			differences, err := db.SchemaRuleDrift(context.Background(), "addresses", rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(differences) != tc.differences {
+				t.Errorf("unexpected differences. Expected %d, got %v", tc.differences, differences)
+			}
+		})
+	}
+}
+
+func equalJSON(v interface{}, expected string) (bool, error) {
+	m, err := json.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	var a, b interface{}
+	if err := json.Unmarshal(m, &a); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(expected), &b); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(a, b), nil
+}
@@ -0,0 +1,350 @@
+// Package migrations applies versioned schema and data migrations to an ArangoDB database.
+//
+// The applied migrations are recorded in a bookkeeping collection, along with
+// a checksum detecting the migrations modified after being applied. A lock
+// document in the same collection prevents concurrent migrations.
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// ErrLocked is returned when the migrations are locked by another migrator.
+var ErrLocked = errors.New("the migrations are locked by another migrator")
+
+// lockKey is the key of the lock document in the bookkeeping collection.
+const lockKey = "lock"
+
+// Migration is a named migration step, written as Go functions or AQL queries.
+type Migration struct {
+	// The unique name of the migration.
+	Name string
+	// Up applies the migration. Either Up or UpAQL must be set.
+	Up func(ctx context.Context, db *arangolite.Database) error
+	// UpAQL is an AQL query applying the migration.
+	UpAQL string
+	// Down reverts the migration. Either Down or DownAQL must be set for the migration to be reverted.
+	Down func(ctx context.Context, db *arangolite.Database) error
+	// DownAQL is an AQL query reverting the migration.
+	DownAQL string
+}
+
+// Checksum returns the checksum of the migration. As the code of Go functions
+// can't be hashed, it only covers the name and the AQL queries.
+func (m Migration) Checksum() string {
+	h := sha256.New()
+	h.Write([]byte(m.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(m.UpAQL))
+	h.Write([]byte{0})
+	h.Write([]byte(m.DownAQL))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Record is the bookkeeping document of an applied migration.
+type Record struct {
+	arangolite.Document
+	// The position of the migration in the migration list, ordering the records.
+	Index     int       `json:"index"`
+	Name      string    `json:"name"`
+	Checksum  string    `json:"checksum"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+type lock struct {
+	arangolite.Document
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Option sets an option of the migrator.
+type Option func(m *Migrator)
+
+// OptCollection sets the name of the bookkeeping collection. Defaults to "_migrations".
+func OptCollection(collection string) Option {
+	return func(m *Migrator) {
+		m.collection = collection
+	}
+}
+
+// OptOwner sets the name identifying the migrator in the lock document.
+// Defaults to the host name and the process ID.
+func OptOwner(owner string) Option {
+	return func(m *Migrator) {
+		m.owner = owner
+	}
+}
+
+// OptLockTTL sets the duration after which the lock of a migrator is considered
+// abandoned and can be taken over. Defaults to 15 minutes.
+func OptLockTTL(ttl time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockTTL = ttl
+	}
+}
+
+// Migrator applies and reverts an ordered list of migrations.
+type Migrator struct {
+	db         *arangolite.Database
+	migrations []Migration
+	collection string
+	owner      string
+	lockTTL    time.Duration
+}
+
+// New returns a new Migrator of the given migrations, applied in order.
+func New(db *arangolite.Database, migrations []Migration, opts ...Option) *Migrator {
+	hostname, _ := os.Hostname()
+	m := &Migrator{
+		db:         db,
+		migrations: migrations,
+		collection: "_migrations",
+		owner:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		lockTTL:    15 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Applied returns the records of the applied migrations, in order.
+// It does not write to the database, so no migration is applied as long as
+// the bookkeeping collection does not exist.
+func (m *Migrator) Applied(ctx context.Context) ([]Record, error) {
+	records := []Record{}
+	q := requests.NewAQL(`
+		FOR r IN @@collection
+		FILTER r.name != null
+		SORT r.index
+		RETURN r
+	`).Bind("@collection", m.collection)
+	// 1203 - ERROR_ARANGO_DATA_SOURCE_NOT_FOUND
+	if err := m.db.Run(ctx, &records, q); err != nil && !arangolite.HasErrorNum(err, 1203) {
+		return nil, withMessage(err, "could not list the applied migrations")
+	}
+
+	return records, nil
+}
+
+// Pending returns the migrations that are not applied yet, without applying them.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.pending(records)
+}
+
+// pending checks the applied migrations against the migration list and returns the pending ones.
+func (m *Migrator) pending(records []Record) ([]Migration, error) {
+	if len(records) > len(m.migrations) {
+		return nil, fmt.Errorf("%d migrations are applied but only %d are known", len(records), len(m.migrations))
+	}
+	for i, r := range records {
+		migration := m.migrations[i]
+		if r.Name != migration.Name {
+			return nil, fmt.Errorf("the applied migration %d is %s instead of %s", i, r.Name, migration.Name)
+		}
+		if r.Checksum != migration.Checksum() {
+			return nil, fmt.Errorf("the migration %s was modified after being applied", r.Name)
+		}
+	}
+	return m.migrations[len(records):], nil
+}
+
+// Up applies all the pending migrations and returns their names.
+// On error, the names of the migrations applied before the failure are also returned.
+func (m *Migrator) Up(ctx context.Context) ([]string, error) {
+	l, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock(l)
+
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := m.pending(records)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []string{}
+	for i, migration := range pending {
+		if err := m.refresh(ctx, l); err != nil {
+			return applied, err
+		}
+		if err := run(ctx, m.db, migration.Up, migration.UpAQL); err != nil {
+			return applied, withMessage(err, fmt.Sprintf("could not apply the migration %s", migration.Name))
+		}
+
+		record := &Record{
+			Index:     len(records) + i,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum(),
+			AppliedAt: time.Now().UTC(),
+		}
+		if err := m.db.Run(ctx, nil, &requests.CreateDocument{Collection: m.collection, Document: record}); err != nil {
+			return applied, withMessage(err, fmt.Sprintf("could not record the migration %s", migration.Name))
+		}
+		applied = append(applied, migration.Name)
+	}
+
+	return applied, nil
+}
+
+// Down reverts the given number of applied migrations, most recent first, and returns their names.
+// On error, the names of the migrations reverted before the failure are also returned.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]string, error) {
+	l, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock(l)
+
+	records, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.pending(records); err != nil {
+		return nil, err
+	}
+	if steps > len(records) {
+		steps = len(records)
+	}
+
+	reverted := []string{}
+	for i := len(records) - 1; i >= len(records)-steps; i-- {
+		migration := m.migrations[i]
+		if migration.Down == nil && migration.DownAQL == "" {
+			return reverted, fmt.Errorf("the migration %s can't be reverted", migration.Name)
+		}
+		if err := m.refresh(ctx, l); err != nil {
+			return reverted, err
+		}
+		if err := run(ctx, m.db, migration.Down, migration.DownAQL); err != nil {
+			return reverted, withMessage(err, fmt.Sprintf("could not revert the migration %s", migration.Name))
+		}
+
+		q := &requests.DeleteDocument{Collection: m.collection, Key: records[i].Key}
+		if err := m.db.Run(ctx, nil, q); err != nil {
+			return reverted, withMessage(err, fmt.Sprintf("could not remove the record of the migration %s", migration.Name))
+		}
+		reverted = append(reverted, migration.Name)
+	}
+
+	return reverted, nil
+}
+
+func run(ctx context.Context, db *arangolite.Database, fn func(context.Context, *arangolite.Database) error, query string) error {
+	if fn != nil {
+		return fn(ctx, db)
+	}
+	// The query is passed as a parameter so it is not interpreted as a format string.
+	return db.Run(ctx, nil, requests.NewAQL("%s", query))
+}
+
+func (m *Migrator) ensureCollection(ctx context.Context) error {
+	q := &requests.CreateCollection{Name: m.collection, IsSystem: strings.HasPrefix(m.collection, "_")}
+	// 1207 - ERROR_ARANGO_DUPLICATE_NAME
+	if err := m.db.Run(ctx, nil, q); err != nil && !arangolite.HasErrorNum(err, 1207) {
+		return withMessage(err, "could not create the migrations collection")
+	}
+	return nil
+}
+
+// lock takes the migration lock, taking over an expired one if needed.
+// The returned lock holds the revision identifying it until it is refreshed or released.
+func (m *Migrator) lock(ctx context.Context) (*lock, error) {
+	if err := m.ensureCollection(ctx); err != nil {
+		return nil, err
+	}
+
+	l := &lock{Owner: m.owner, ExpiresAt: time.Now().Add(m.lockTTL).UTC()}
+	l.Key = lockKey
+	err := m.db.Run(ctx, l, &requests.CreateDocument{Collection: m.collection, Document: l})
+	if err == nil {
+		return l, nil
+	}
+	if !arangolite.IsErrUnique(err) {
+		return nil, withMessage(err, "could not lock the migrations")
+	}
+
+	current := &lock{}
+	if err := m.db.Run(ctx, current, &requests.GetDocument{Collection: m.collection, Key: lockKey}); err != nil {
+		return nil, withMessage(err, "could not read the migrations lock")
+	}
+	if time.Now().Before(current.ExpiresAt) {
+		return nil, ErrLocked
+	}
+
+	// The revision check makes sure only one migrator takes over the expired lock.
+	l.Rev = current.Rev
+	switch err := m.replaceLock(ctx, l); {
+	case err == ErrLocked:
+		return nil, err
+	case err != nil:
+		return nil, withMessage(err, "could not take over the expired migrations lock")
+	}
+
+	return l, nil
+}
+
+// refresh extends the expiry of the held lock, so it is not taken over during a long migration.
+// It returns ErrLocked if the lock was already taken over.
+func (m *Migrator) refresh(ctx context.Context, l *lock) error {
+	l.ExpiresAt = time.Now().Add(m.lockTTL).UTC()
+	switch err := m.replaceLock(ctx, l); {
+	case err == ErrLocked:
+		return err
+	case err != nil:
+		return withMessage(err, "could not refresh the migrations lock")
+	}
+	return nil
+}
+
+// replaceLock replaces the lock document if its revision is still the one of the given lock,
+// and updates the revision of the given lock. It returns ErrLocked otherwise.
+func (m *Migrator) replaceLock(ctx context.Context, l *lock) error {
+	ignoreRevs := false
+	q := &requests.ReplaceDocument{Collection: m.collection, Key: lockKey, Document: l, IgnoreRevs: &ignoreRevs}
+	if err := m.db.Run(ctx, l, q); err != nil {
+		if arangolite.HasStatusCode(err, 412) {
+			return ErrLocked
+		}
+		return err
+	}
+	return nil
+}
+
+// unlock releases the held lock, even if the caller context is cancelled.
+// A lock taken over by another migrator is left untouched.
+func (m *Migrator) unlock(l *lock) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only the batch removal checks the revisions without a header.
+	ignoreRevs := false
+	results := arangolite.DocumentResults{}
+	q := &requests.DeleteDocuments{Collection: m.collection, Documents: []*lock{l}, IgnoreRevs: &ignoreRevs}
+	if err := m.db.Run(ctx, &results, q); err != nil {
+		return err
+	}
+	return results.Err()
+}
+
+func withMessage(err error, message string) error {
+	return fmt.Errorf("%s: %s", message, err.Error())
+}
@@ -0,0 +1,347 @@
+package migrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/migrations"
+)
+
+// TestUp runs tests on the migrator Up and Pending methods.
+func TestUp(t *testing.T) {
+	first := migrations.Migration{Name: "create nodes", UpAQL: `INSERT {} INTO nodes`}
+	second := migrations.Migration{Name: "rename nodes", UpAQL: `FOR n IN nodes UPDATE n WITH {name: 'node'} IN nodes`}
+
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		records    []migrations.Record
+		lock       string
+		migrations []migrations.Migration
+		// Expected results
+		testErr         func(err error) bool
+		expectedPending []string
+		expectedApplied []string
+		expectedQueries []string
+	}{
+		{
+			description:     "no applied migration",
+			migrations:      []migrations.Migration{first, second},
+			testErr:         func(err error) bool { return err == nil },
+			expectedPending: []string{"create nodes", "rename nodes"},
+			expectedApplied: []string{"create nodes", "rename nodes"},
+			expectedQueries: []string{first.UpAQL, second.UpAQL},
+		},
+		{
+			description:     "one applied migration",
+			records:         []migrations.Record{{Name: first.Name, Checksum: first.Checksum()}},
+			migrations:      []migrations.Migration{first, second},
+			testErr:         func(err error) bool { return err == nil },
+			expectedPending: []string{"rename nodes"},
+			expectedApplied: []string{"rename nodes"},
+			expectedQueries: []string{second.UpAQL},
+		},
+		{
+			description: "modified migration",
+			records:     []migrations.Record{{Name: first.Name, Checksum: "foobar"}},
+			migrations:  []migrations.Migration{first, second},
+			testErr: func(err error) bool {
+				return err != nil && strings.Contains(err.Error(), "modified")
+			},
+		},
+		{
+			description:     "locked migrations",
+			lock:            time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			migrations:      []migrations.Migration{first, second},
+			testErr:         func(err error) bool { return err == migrations.ErrLocked },
+			expectedPending: []string{"create nodes", "rename nodes"},
+		},
+		{
+			description:     "expired lock",
+			lock:            time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			migrations:      []migrations.Migration{first},
+			testErr:         func(err error) bool { return err == nil },
+			expectedPending: []string{"create nodes"},
+			expectedApplied: []string{"create nodes"},
+			expectedQueries: []string{first.UpAQL},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := newMockServer(tc.records, tc.lock)
+			defer server.Close()
+			db := arangolite.NewDatabase(arangolite.OptEndpoint(server.URL))
+			m := migrations.New(db, tc.migrations, migrations.OptOwner("test"))
+
+			// The pending migrations are listed without taking the lock.
+			pending, err := m.Pending(ctx)
+			if err != nil {
+				if ok := tc.testErr(err); !ok {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if names := migrationNames(pending); !reflect.DeepEqual(names, tc.expectedPending) {
+				t.Errorf("unexpected pending migrations. Expected %v, got %v", tc.expectedPending, names)
+			}
+
+			applied, err := m.Up(ctx)
+			if ok := tc.testErr(err); !ok {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(applied, tc.expectedApplied) {
+				t.Errorf("unexpected applied migrations. Expected %v, got %v", tc.expectedApplied, applied)
+			}
+			if !reflect.DeepEqual(server.queries, tc.expectedQueries) {
+				t.Errorf("unexpected queries. Expected %v, got %v", tc.expectedQueries, server.queries)
+			}
+			if len(server.records) != len(tc.records)+len(applied) {
+				t.Errorf("unexpected records. Expected %d, got %d", len(tc.records)+len(applied), len(server.records))
+			}
+			for i, record := range server.records {
+				if record.Index != i {
+					t.Errorf("unexpected index of the record %s. Expected %d, got %d", record.Name, i, record.Index)
+				}
+			}
+			if server.lock != "" {
+				t.Errorf("the lock was not released")
+			}
+		})
+	}
+}
+
+// TestDown runs tests on the migrator Down method.
+func TestDown(t *testing.T) {
+	reverted := []string{}
+	down := func(name string) func(context.Context, *arangolite.Database) error {
+		return func(context.Context, *arangolite.Database) error {
+			reverted = append(reverted, name)
+			return nil
+		}
+	}
+	list := []migrations.Migration{
+		{Name: "first", Up: down("first"), Down: down("first")},
+		{Name: "second", Up: down("second"), Down: down("second")},
+	}
+	records := []migrations.Record{
+		{Document: arangolite.Document{Key: "1"}, Name: "first", Checksum: list[0].Checksum()},
+		{Document: arangolite.Document{Key: "2"}, Name: "second", Checksum: list[1].Checksum()},
+	}
+
+	server := newMockServer(records, "")
+	defer server.Close()
+	db := arangolite.NewDatabase(arangolite.OptEndpoint(server.URL))
+
+	names, err := migrations.New(db, list).Down(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(names, []string{"second"}) || !reflect.DeepEqual(reverted, names) {
+		t.Errorf("unexpected reverted migrations. Expected [second], got %v", names)
+	}
+	if len(server.records) != 1 || server.records[0].Name != "first" {
+		t.Errorf("unexpected records: %v", server.records)
+	}
+}
+
+// TestPendingWithoutCollection checks that listing the pending migrations does not create the bookkeeping collection.
+func TestPendingWithoutCollection(t *testing.T) {
+	server := newMockServer(nil, "")
+	defer server.Close()
+	server.missing = true
+	db := arangolite.NewDatabase(arangolite.OptEndpoint(server.URL))
+
+	pending, err := migrations.New(db, []migrations.Migration{{Name: "first", UpAQL: `RETURN 1`}}).Pending(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if names := migrationNames(pending); !reflect.DeepEqual(names, []string{"first"}) {
+		t.Errorf("unexpected pending migrations. Expected [first], got %v", names)
+	}
+	if !server.missing {
+		t.Errorf("the bookkeeping collection was created")
+	}
+}
+
+// TestLockTakenOver runs a migration during which the lock is taken over by another migrator.
+func TestLockTakenOver(t *testing.T) {
+	server := newMockServer(nil, "")
+	defer server.Close()
+	db := arangolite.NewDatabase(arangolite.OptEndpoint(server.URL))
+
+	list := []migrations.Migration{
+		{Name: "first", Up: func(context.Context, *arangolite.Database) error {
+			server.takeOver()
+			return nil
+		}},
+		{Name: "second", UpAQL: `RETURN 1`},
+	}
+	applied, err := migrations.New(db, list, migrations.OptOwner("test")).Up(context.Background())
+	if err != migrations.ErrLocked {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(applied, []string{"first"}) {
+		t.Errorf("unexpected applied migrations. Expected [first], got %v", applied)
+	}
+	if len(server.queries) != 0 {
+		t.Errorf("the second migration was applied")
+	}
+	if server.lock == "" || server.lockOwner != "other" {
+		t.Errorf("the lock of the other migrator was released")
+	}
+}
+
+func migrationNames(list []migrations.Migration) []string {
+	names := []string{}
+	for _, m := range list {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+// mockServer simulates the bookkeeping collection of the migrations.
+type mockServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	records []migrations.Record
+	// The expiry, owner and revision of the lock document, if any.
+	lock      string
+	lockOwner string
+	lockRev   int
+	queries   []string
+	// The bookkeeping collection does not exist until it is created.
+	missing bool
+}
+
+func newMockServer(records []migrations.Record, lock string) *mockServer {
+	s := &mockServer{records: append([]migrations.Record{}, records...), lock: lock, lockOwner: "other"}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// takeOver simulates another migrator taking the lock over.
+func (s *mockServer) takeOver() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lock = time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	s.lockOwner = "other"
+	s.lockRev++
+}
+
+func (s *mockServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := ioutil.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.TrimPrefix(r.URL.Path, "/_db/_system")
+
+	switch {
+	case path == "/_api/collection":
+		if s.missing {
+			s.missing = false
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error":true,"code":409,"errorNum":1207,"errorMessage":"duplicate name"}`)
+	case path == "/_api/cursor":
+		q := struct {
+			Query string `json:"query"`
+		}{}
+		json.Unmarshal(body, &q)
+		if strings.Contains(q.Query, "@@collection") {
+			if s.missing {
+				w.WriteHeader(404)
+				fmt.Fprint(w, `{"error":true,"code":404,"errorNum":1203,"errorMessage":"collection or view not found"}`)
+				return
+			}
+			result, _ := json.Marshal(s.records)
+			fmt.Fprintf(w, `{"result":%s,"hasMore":false}`, result)
+			return
+		}
+		s.queries = append(s.queries, q.Query)
+		fmt.Fprint(w, `{"result":[],"hasMore":false}`)
+	case path == "/_api/document/_migrations/lock":
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"_key":"lock","_rev":"%d","owner":%q,"expiresAt":"%s"}`, s.lockRev, s.lockOwner, s.lock)
+		case "PUT":
+			l := struct {
+				Rev       string `json:"_rev"`
+				Owner     string `json:"owner"`
+				ExpiresAt string `json:"expiresAt"`
+			}{}
+			json.Unmarshal(body, &l)
+			if r.URL.Query().Get("ignoreRevs") != "false" || l.Rev != fmt.Sprint(s.lockRev) {
+				w.WriteHeader(412)
+				fmt.Fprint(w, `{"error":true,"code":412,"errorNum":1200,"errorMessage":"conflict"}`)
+				return
+			}
+			s.lock, s.lockOwner = l.ExpiresAt, l.Owner
+			s.lockRev++
+			fmt.Fprintf(w, `{"_key":"lock","_rev":"%d"}`, s.lockRev)
+		}
+	case path == "/_api/document/_migrations" && r.Method == "DELETE":
+		docs := []struct {
+			Key string `json:"_key"`
+			Rev string `json:"_rev"`
+		}{}
+		json.Unmarshal(body, &docs)
+		w.WriteHeader(202)
+		if len(docs) != 1 || docs[0].Key != "lock" || docs[0].Rev != fmt.Sprint(s.lockRev) ||
+			r.URL.Query().Get("ignoreRevs") != "false" {
+			fmt.Fprint(w, `[{"error":true,"errorNum":1200,"errorMessage":"conflict"}]`)
+			return
+		}
+		s.lock = ""
+		fmt.Fprint(w, `[{"_key":"lock"}]`)
+	case path == "/_api/document/_migrations" && r.Method == "POST":
+		if strings.Contains(string(body), `"_key":"lock"`) {
+			if s.lock != "" {
+				w.WriteHeader(409)
+				fmt.Fprint(w, `{"error":true,"code":409,"errorNum":1210,"errorMessage":"unique constraint violated"}`)
+				return
+			}
+			l := struct {
+				Owner     string `json:"owner"`
+				ExpiresAt string `json:"expiresAt"`
+			}{}
+			json.Unmarshal(body, &l)
+			s.lock, s.lockOwner = l.ExpiresAt, l.Owner
+			s.lockRev++
+			fmt.Fprintf(w, `{"_key":"lock","_rev":"%d"}`, s.lockRev)
+			return
+		}
+		record := migrations.Record{}
+		json.Unmarshal(body, &record)
+		s.records = append(s.records, record)
+		fmt.Fprint(w, `{}`)
+	case strings.HasPrefix(path, "/_api/document/_migrations/") && r.Method == "DELETE":
+		key := strings.TrimPrefix(path, "/_api/document/_migrations/")
+		for i, record := range s.records {
+			if record.Key == key {
+				s.records = append(s.records[:i], s.records[i+1:]...)
+				break
+			}
+		}
+		fmt.Fprint(w, `{}`)
+	default:
+		w.WriteHeader(500)
+	}
+}
@@ -0,0 +1,246 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AnalyzerProperties are the properties of an analyzer type.
+type AnalyzerProperties interface {
+	// The analyzer type the properties belong to.
+	AnalyzerType() string
+}
+
+// IdentityAnalyzer are the properties of an identity analyzer, keeping the values as they are.
+// It has no properties.
+type IdentityAnalyzer struct{}
+
+func (p *IdentityAnalyzer) AnalyzerType() string {
+	return "identity"
+}
+
+// EdgeNgram are the edge n-grams generated by a text analyzer.
+type EdgeNgram struct {
+	Min              int  `json:"min,omitempty"`
+	Max              int  `json:"max,omitempty"`
+	PreserveOriginal bool `json:"preserveOriginal,omitempty"`
+}
+
+// TextAnalyzer are the properties of a text analyzer, tokenizing the text into words.
+type TextAnalyzer struct {
+	// e.g. "en" or "fr".
+	Locale string `json:"locale"`
+	// Either "lower" (default), "upper" or "none".
+	Case string `json:"case,omitempty"`
+	// Keep the accents. Defaults to false.
+	Accent *bool `json:"accent,omitempty"`
+	// Apply the stemming. Defaults to true.
+	Stemming  *bool      `json:"stemming,omitempty"`
+	EdgeNgram *EdgeNgram `json:"edgeNgram,omitempty"`
+	// The words to ignore. Defaults to the words of StopwordsPath if nil.
+	Stopwords     []string `json:"stopwords,omitempty"`
+	StopwordsPath string   `json:"stopwordsPath,omitempty"`
+}
+
+func (p *TextAnalyzer) AnalyzerType() string {
+	return "text"
+}
+
+// NgramAnalyzer are the properties of a ngram analyzer.
+type NgramAnalyzer struct {
+	Min              int    `json:"min"`
+	Max              int    `json:"max"`
+	PreserveOriginal bool   `json:"preserveOriginal"`
+	StartMarker      string `json:"startMarker,omitempty"`
+	EndMarker        string `json:"endMarker,omitempty"`
+	// Either "binary" (default) or "utf8".
+	StreamType string `json:"streamType,omitempty"`
+}
+
+func (p *NgramAnalyzer) AnalyzerType() string {
+	return "ngram"
+}
+
+// NormAnalyzer are the properties of a norm analyzer, normalizing the text without tokenizing it.
+type NormAnalyzer struct {
+	Locale string `json:"locale"`
+	// Either "lower", "upper" or "none" (default).
+	Case string `json:"case,omitempty"`
+	// Keep the accents. Defaults to true.
+	Accent *bool `json:"accent,omitempty"`
+}
+
+func (p *NormAnalyzer) AnalyzerType() string {
+	return "norm"
+}
+
+// StemAnalyzer are the properties of a stem analyzer, stemming the text without tokenizing it.
+type StemAnalyzer struct {
+	Locale string `json:"locale"`
+}
+
+func (p *StemAnalyzer) AnalyzerType() string {
+	return "stem"
+}
+
+// DelimiterAnalyzer are the properties of a delimiter analyzer, splitting the text at a delimiter.
+type DelimiterAnalyzer struct {
+	Delimiter string `json:"delimiter"`
+}
+
+func (p *DelimiterAnalyzer) AnalyzerType() string {
+	return "delimiter"
+}
+
+// PipelineAnalyzer are the properties of a pipeline analyzer, applying analyzers in order.
+type PipelineAnalyzer struct {
+	Pipeline []AnalyzerProperties
+}
+
+func (p *PipelineAnalyzer) AnalyzerType() string {
+	return "pipeline"
+}
+
+// MarshalJSON encodes the pipeline analyzers with their type.
+func (p *PipelineAnalyzer) MarshalJSON() ([]byte, error) {
+	pipeline := make([]analyzerDefinition, len(p.Pipeline))
+	for i, properties := range p.Pipeline {
+		def, err := newAnalyzerDefinition(properties)
+		if err != nil {
+			return nil, err
+		}
+		pipeline[i] = def
+	}
+	return json.Marshal(struct {
+		Pipeline []analyzerDefinition `json:"pipeline"`
+	}{pipeline})
+}
+
+// GeoOptions are the options of the geo index of a geojson analyzer.
+type GeoOptions struct {
+	MaxCells int `json:"maxCells,omitempty"`
+	MinLevel int `json:"minLevel,omitempty"`
+	MaxLevel int `json:"maxLevel,omitempty"`
+}
+
+// GeoJSONAnalyzer are the properties of a geojson analyzer.
+type GeoJSONAnalyzer struct {
+	// Either "shape" (default), "centroid" or "point".
+	Type    string      `json:"type,omitempty"`
+	Options *GeoOptions `json:"options,omitempty"`
+	// Use the polygon semantics of the servers prior to 3.10.
+	Legacy bool `json:"legacy,omitempty"`
+}
+
+func (p *GeoJSONAnalyzer) AnalyzerType() string {
+	return "geojson"
+}
+
+type analyzerDefinition struct {
+	Type       string          `json:"type"`
+	Properties json.RawMessage `json:"properties,omitempty"`
+}
+
+// newAnalyzerDefinition encodes the analyzer properties with their type.
+// Nil properties define an identity analyzer, and empty properties are omitted.
+func newAnalyzerDefinition(properties AnalyzerProperties) (analyzerDefinition, error) {
+	if properties == nil {
+		properties = &IdentityAnalyzer{}
+	}
+	def := analyzerDefinition{Type: properties.AnalyzerType()}
+	m, err := json.Marshal(properties)
+	if err != nil {
+		return def, err
+	}
+	if string(m) != "{}" {
+		def.Properties = m
+	}
+	return def, nil
+}
+
+// CreateAnalyzer creates an analyzer. The result is an AnalyzerInfo.
+type CreateAnalyzer struct {
+	Name string
+	// Defaults to an IdentityAnalyzer if nil.
+	Properties AnalyzerProperties
+	// The features of the analyzer: "frequency", "norm", "position" and/or "offset".
+	Features []string
+}
+
+func (r *CreateAnalyzer) Path() string {
+	return "/_api/analyzer"
+}
+
+func (r *CreateAnalyzer) Method() string {
+	return "POST"
+}
+
+func (r *CreateAnalyzer) Generate() []byte {
+	def, _ := newAnalyzerDefinition(r.Properties)
+	m, _ := json.Marshal(struct {
+		Name string `json:"name"`
+		analyzerDefinition
+		Features []string `json:"features,omitempty"`
+	}{r.Name, def, r.Features})
+	return m
+}
+
+// AnalyzerInfo describes an analyzer.
+type AnalyzerInfo struct {
+	// The analyzer name, prefixed by the database name and "::" for the custom analyzers.
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	Properties json.RawMessage `json:"properties"`
+	Features   []string        `json:"features"`
+}
+
+// ListAnalyzers lists the analyzers of the current database. The result can be decoded in a []AnalyzerInfo.
+type ListAnalyzers struct{}
+
+func (r *ListAnalyzers) Path() string {
+	return "/_api/analyzer"
+}
+
+func (r *ListAnalyzers) Method() string {
+	return "GET"
+}
+
+func (r *ListAnalyzers) Generate() []byte {
+	return nil
+}
+
+// GetAnalyzer retrieves an analyzer. The result is an AnalyzerInfo.
+type GetAnalyzer struct {
+	Name string
+}
+
+func (r *GetAnalyzer) Path() string {
+	return fmt.Sprintf("/_api/analyzer/%s", r.Name)
+}
+
+func (r *GetAnalyzer) Method() string {
+	return "GET"
+}
+
+func (r *GetAnalyzer) Generate() []byte {
+	return nil
+}
+
+// DeleteAnalyzer deletes an analyzer.
+type DeleteAnalyzer struct {
+	Name string
+	// Delete the analyzer even if it is used by views or inverted indexes.
+	Force bool
+}
+
+func (r *DeleteAnalyzer) Path() string {
+	return fmt.Sprintf("/_api/analyzer/%s?force=%v", r.Name, r.Force)
+}
+
+func (r *DeleteAnalyzer) Method() string {
+	return "DELETE"
+}
+
+func (r *DeleteAnalyzer) Generate() []byte {
+	return nil
+}
@@ -0,0 +1,86 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestCreateAnalyzer runs tests on the CreateAnalyzer request.
+func TestCreateAnalyzer(t *testing.T) {
+	accent := false
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request *requests.CreateAnalyzer
+		// Expected results
+		output string
+	}{
+		{
+			description: "text analyzer",
+			request: &requests.CreateAnalyzer{
+				Name:       "text_custom",
+				Properties: &requests.TextAnalyzer{Locale: "en", Accent: &accent, Stopwords: []string{"the"}},
+				Features:   []string{"frequency", "position"},
+			},
+			output: `{"name":"text_custom","type":"text","properties":{"locale":"en","accent":false,"stopwords":["the"]},` +
+				`"features":["frequency","position"]}`,
+		},
+		{
+			description: "pipeline analyzer",
+			request: &requests.CreateAnalyzer{
+				Name: "pipeline_custom",
+				Properties: &requests.PipelineAnalyzer{Pipeline: []requests.AnalyzerProperties{
+					&requests.DelimiterAnalyzer{Delimiter: ","},
+					&requests.NormAnalyzer{Locale: "en", Case: "lower"},
+				}},
+			},
+			output: `{"name":"pipeline_custom","type":"pipeline","properties":{"pipeline":[` +
+				`{"type":"delimiter","properties":{"delimiter":","}},` +
+				`{"type":"norm","properties":{"locale":"en","case":"lower"}}]}}`,
+		},
+		{
+			description: "geojson analyzer",
+			request: &requests.CreateAnalyzer{
+				Name:       "geo_custom",
+				Properties: &requests.GeoJSONAnalyzer{Type: "centroid"},
+			},
+			output: `{"name":"geo_custom","type":"geojson","properties":{"type":"centroid"}}`,
+		},
+		{
+			description: "identity analyzer",
+			request: &requests.CreateAnalyzer{
+				Name:       "identity_custom",
+				Properties: &requests.IdentityAnalyzer{},
+				Features:   []string{"frequency", "norm"},
+			},
+			output: `{"name":"identity_custom","type":"identity","features":["frequency","norm"]}`,
+		},
+		{
+			description: "analyzer without properties",
+			request:     &requests.CreateAnalyzer{Name: "identity_custom"},
+			output:      `{"name":"identity_custom","type":"identity"}`,
+		},
+		{
+			description: "pipeline analyzer with empty properties",
+			request: &requests.CreateAnalyzer{
+				Name: "pipeline_custom",
+				Properties: &requests.PipelineAnalyzer{Pipeline: []requests.AnalyzerProperties{
+					&requests.IdentityAnalyzer{},
+					&requests.StemAnalyzer{Locale: "en"},
+				}},
+			},
+			output: `{"name":"pipeline_custom","type":"pipeline","properties":{"pipeline":[` +
+				`{"type":"identity"},{"type":"stem","properties":{"locale":"en"}}]}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.request.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.request.Generate())
+			}
+		})
+	}
+}
@@ -4,15 +4,39 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
 // AQL represents an AQL query.
 type AQL struct {
-	query     string
-	bindVars  map[string]interface{}
-	cache     *bool
-	batchSize int
+	query       string
+	bindVars    map[string]interface{}
+	cache       *bool
+	batchSize   int
+	count       *bool
+	ttl         int
+	memoryLimit int64
+	options     *aqlOptions
+	// Sent as a header, as the database doesn't read it from the options.
+	allowDirtyReads bool
+}
+
+// aqlOptions are the extra options of an AQL query, serialized in the "options" attribute.
+type aqlOptions struct {
+	FullCount         *bool         `json:"fullCount,omitempty"`
+	FillBlockCache    *bool         `json:"fillBlockCache,omitempty"`
+	MaxRuntime        float64       `json:"maxRuntime,omitempty"`
+	MaxWarningCount   int           `json:"maxWarningCount,omitempty"`
+	FailOnWarning     *bool         `json:"failOnWarning,omitempty"`
+	Stream            *bool         `json:"stream,omitempty"`
+	Profile           int           `json:"profile,omitempty"`
+	SatelliteSyncWait float64       `json:"satelliteSyncWait,omitempty"`
+	Optimizer         *aqlOptimizer `json:"optimizer,omitempty"`
+}
+
+type aqlOptimizer struct {
+	Rules []string `json:"rules"`
 }
 
 // NewAQL returns a new AQL object.
@@ -33,6 +57,98 @@ func (a *AQL) BatchSize(size int) *AQL {
 	return a
 }
 
+// Count makes the database return the total number of results.
+func (a *AQL) Count(enable bool) *AQL {
+	a.count = &enable
+	return a
+}
+
+// TTL sets the time to live of the query cursor in the database, in seconds.
+func (a *AQL) TTL(seconds int) *AQL {
+	a.ttl = seconds
+	return a
+}
+
+// MemoryLimit sets the maximum amount of memory the query can use, in bytes.
+func (a *AQL) MemoryLimit(bytes int64) *AQL {
+	a.memoryLimit = bytes
+	return a
+}
+
+// FullCount makes the database return the number of results the query would have
+// returned without its last top-level LIMIT. Useful for pagination.
+func (a *AQL) FullCount(enable bool) *AQL {
+	a.opts().FullCount = &enable
+	return a
+}
+
+// FillBlockCache enables/disables the storage of the data read by the query in the block cache.
+func (a *AQL) FillBlockCache(enable bool) *AQL {
+	a.opts().FillBlockCache = &enable
+	return a
+}
+
+// MaxRuntime sets the maximum duration of the query, in seconds.
+func (a *AQL) MaxRuntime(seconds float64) *AQL {
+	a.opts().MaxRuntime = seconds
+	return a
+}
+
+// MaxWarningCount sets the maximum number of warnings returned by the query.
+func (a *AQL) MaxWarningCount(count int) *AQL {
+	a.opts().MaxWarningCount = count
+	return a
+}
+
+// FailOnWarning makes the query fail instead of returning warnings.
+func (a *AQL) FailOnWarning(enable bool) *AQL {
+	a.opts().FailOnWarning = &enable
+	return a
+}
+
+// Stream makes the database execute the query lazily, batch by batch.
+func (a *AQL) Stream(enable bool) *AQL {
+	a.opts().Stream = &enable
+	return a
+}
+
+// Profile sets the level of profiling information returned by the query:
+// 0 disables profiling, 1 returns the timing of each phase and 2 also returns
+// the statistics of each execution node.
+func (a *AQL) Profile(level int) *AQL {
+	a.opts().Profile = level
+	return a
+}
+
+// SatelliteSyncWait sets how long a DB-Server waits for the satellite collections
+// involved in the query to be synchronized, in seconds.
+func (a *AQL) SatelliteSyncWait(seconds float64) *AQL {
+	a.opts().SatelliteSyncWait = seconds
+	return a
+}
+
+// AllowDirtyReads allows the query to read from followers in a cluster.
+func (a *AQL) AllowDirtyReads(enable bool) *AQL {
+	a.allowDirtyReads = enable
+	return a
+}
+
+// OptimizerRules enables or disables optimizer rules, e.g. "-all" or "+use-indexes".
+func (a *AQL) OptimizerRules(rules ...string) *AQL {
+	if a.opts().Optimizer == nil {
+		a.options.Optimizer = &aqlOptimizer{}
+	}
+	a.options.Optimizer.Rules = append(a.options.Optimizer.Rules, rules...)
+	return a
+}
+
+func (a *AQL) opts() *aqlOptions {
+	if a.options == nil {
+		a.options = &aqlOptions{}
+	}
+	return a.options
+}
+
 // Bind sets the name and value of a bind parameter
 // Binding parameters prevents AQL injection
 func (a *AQL) Bind(name string, value interface{}) *AQL {
@@ -43,6 +159,15 @@ func (a *AQL) Bind(name string, value interface{}) *AQL {
 	return a
 }
 
+// Header returns the headers of the query, used by the database Run and Send methods.
+func (a *AQL) Header() http.Header {
+	h := http.Header{}
+	if a.allowDirtyReads {
+		h.Set("x-arango-allow-dirty-read", "true")
+	}
+	return h
+}
+
 func (a *AQL) Path() string {
 	return "/_api/cursor"
 }
@@ -53,13 +178,26 @@ func (a *AQL) Method() string {
 
 func (a *AQL) Generate() []byte {
 	type AQLFmt struct {
-		Query     string                 `json:"query"`
-		BindVars  map[string]interface{} `json:"bindVars,omitempty"`
-		Cache     *bool                  `json:"cache,omitempty"`
-		BatchSize int                    `json:"batchSize,omitempty"`
+		Query       string                 `json:"query"`
+		BindVars    map[string]interface{} `json:"bindVars,omitempty"`
+		Cache       *bool                  `json:"cache,omitempty"`
+		BatchSize   int                    `json:"batchSize,omitempty"`
+		Count       *bool                  `json:"count,omitempty"`
+		TTL         int                    `json:"ttl,omitempty"`
+		MemoryLimit int64                  `json:"memoryLimit,omitempty"`
+		Options     *aqlOptions            `json:"options,omitempty"`
 	}
 
-	jsonAQL, _ := json.Marshal(&AQLFmt{Query: a.query, BindVars: a.bindVars, Cache: a.cache, BatchSize: a.batchSize})
+	jsonAQL, _ := json.Marshal(&AQLFmt{
+		Query:       a.query,
+		BindVars:    a.bindVars,
+		Cache:       a.cache,
+		BatchSize:   a.batchSize,
+		Count:       a.count,
+		TTL:         a.ttl,
+		MemoryLimit: a.memoryLimit,
+		Options:     a.options,
+	})
 
 	return jsonAQL
 }
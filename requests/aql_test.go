@@ -1,6 +1,7 @@
 package requests_test
 
 import (
+	"strings"
 	"testing"
 
 	"encoding/json"
@@ -134,3 +135,62 @@ func TestAQL(t *testing.T) {
 		})
 	}
 }
+
+// TestAQLOptions runs tests on the serialization of the AQL query options.
+func TestAQLOptions(t *testing.T) {
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		aql *requests.AQL
+		// Expected results
+		output string
+	}{
+		{
+			description: "no options",
+			aql:         requests.NewAQL("FOR x IN documents RETURN x"),
+			output:      `{"query":"FOR x IN documents RETURN x"}`,
+		},
+		{
+			description: "top-level options",
+			aql:         requests.NewAQL("FOR x IN documents RETURN x").Count(true).TTL(60).MemoryLimit(1024),
+			output:      `{"query":"FOR x IN documents RETURN x","count":true,"ttl":60,"memoryLimit":1024}`,
+		},
+		{
+			description: "extra options",
+			aql: requests.NewAQL("FOR x IN documents LIMIT 10 RETURN x").
+				FullCount(true).
+				FillBlockCache(false).
+				MaxRuntime(1.5).
+				MaxWarningCount(5).
+				FailOnWarning(true).
+				Stream(true).
+				Profile(2).
+				SatelliteSyncWait(2).
+				OptimizerRules("-all", "+use-indexes"),
+			output: `{"query":"FOR x IN documents LIMIT 10 RETURN x","options":{"fullCount":true,"fillBlockCache":false,` +
+				`"maxRuntime":1.5,"maxWarningCount":5,"failOnWarning":true,"stream":true,"profile":2,` +
+				`"satelliteSyncWait":2,"optimizer":{"rules":["-all","+use-indexes"]}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.aql.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.aql.Generate())
+			}
+		})
+	}
+
+	// Dirty reads are allowed with a header.
+	q := requests.NewAQL("FOR x IN documents RETURN x")
+	if h := q.Header().Get("x-arango-allow-dirty-read"); h != "" {
+		t.Errorf("unexpected dirty read header %q", h)
+	}
+	if h := q.AllowDirtyReads(true).Header().Get("x-arango-allow-dirty-read"); h != "true" {
+		t.Errorf("unexpected dirty read header. Expected true, got %q", h)
+	}
+	if strings.Contains(string(q.Generate()), "allowDirtyReads") {
+		t.Errorf("the dirty reads are sent in the body: %s", q.Generate())
+	}
+}
@@ -5,19 +5,88 @@ import (
 	"fmt"
 )
 
+// CollectionType is the type of a collection.
+type CollectionType int
+
+const (
+	// DocumentCollection is the type of the document collections.
+	DocumentCollection CollectionType = 2
+	// EdgeCollection is the type of the edge collections.
+	EdgeCollection CollectionType = 3
+)
+
+// KeyGenerator is the algorithm generating the document keys of a collection.
+type KeyGenerator string
+
+const (
+	KeyGeneratorTraditional   KeyGenerator = "traditional"
+	KeyGeneratorAutoincrement KeyGenerator = "autoincrement"
+	KeyGeneratorUUID          KeyGenerator = "uuid"
+	KeyGeneratorPadded        KeyGenerator = "padded"
+)
+
+// KeyOptions are the key generation options of a collection.
+type KeyOptions struct {
+	Type KeyGenerator `json:"type,omitempty"`
+	// Allow the documents to have user-defined keys. Defaults to true.
+	AllowUserKeys *bool `json:"allowUserKeys,omitempty"`
+	// The increment and initial offset of the autoincrement key generator.
+	Increment int `json:"increment,omitempty"`
+	Offset    int `json:"offset,omitempty"`
+	// The last generated key value. Returned by the database.
+	LastValue int64 `json:"lastValue,omitempty"`
+}
+
+// ReplicationFactor is the number of copies of each shard of a collection in a cluster.
+type ReplicationFactor int
+
+// SatelliteReplication replicates a collection on every database server of a cluster.
+const SatelliteReplication ReplicationFactor = -1
+
+// MarshalJSON encodes the satellite replication factor as "satellite".
+func (f ReplicationFactor) MarshalJSON() ([]byte, error) {
+	if f == SatelliteReplication {
+		return []byte(`"satellite"`), nil
+	}
+	return json.Marshal(int(f))
+}
+
+// UnmarshalJSON decodes the "satellite" replication factor as SatelliteReplication.
+func (f *ReplicationFactor) UnmarshalJSON(b []byte) error {
+	if string(b) == `"satellite"` {
+		*f = SatelliteReplication
+		return nil
+	}
+	var factor int
+	if err := json.Unmarshal(b, &factor); err != nil {
+		return err
+	}
+	*f = ReplicationFactor(factor)
+	return nil
+}
+
 // CreateCollection creates a collection in database.
 type CreateCollection struct {
-	JournalSize    int                    `json:"journalSize,omitempty"`
-	KeyOptions     map[string]interface{} `json:"keyOptions,omitempty"`
-	Name           string                 `json:"name"`
-	WaitForSync    bool                   `json:"waitForSync,omitempty"`
-	DoCompact      bool                   `json:"doCompact,omitempty"`
-	IsVolatile     bool                   `json:"isVolatile,omitempty"`
-	ShardKeys      []string               `json:"shardKeys,omitempty"`
-	NumberOfShards int                    `json:"numberOfShards,omitempty"`
-	IsSystem       bool                   `json:"isSystem,omitempty"`
-	Type           int                    `json:"type,omitempty"`
-	IndexBuckets   int                    `json:"indexBuckets,omitempty"`
+	Name string `json:"name"`
+	// Defaults to DocumentCollection.
+	Type           CollectionType        `json:"type,omitempty"`
+	WaitForSync    bool                  `json:"waitForSync,omitempty"`
+	IsSystem       bool                  `json:"isSystem,omitempty"`
+	KeyOptions     *KeyOptions           `json:"keyOptions,omitempty"`
+	CacheEnabled   bool                  `json:"cacheEnabled,omitempty"`
+	Schema         *CollectionSchemaRule `json:"schema,omitempty"`
+	ComputedValues []ComputedValue       `json:"computedValues,omitempty"`
+	// The cluster options.
+	NumberOfShards    int               `json:"numberOfShards,omitempty"`
+	ShardKeys         []string          `json:"shardKeys,omitempty"`
+	ReplicationFactor ReplicationFactor `json:"replicationFactor,omitempty"`
+	// The number of shard copies which must be in sync for the writes to succeed.
+	WriteConcern int `json:"writeConcern,omitempty"`
+	// The name of a collection to copy the sharding from.
+	DistributeShardsLike string `json:"distributeShardsLike,omitempty"`
+	// e.g. "hash", "enterprise-hash-smart-edge".
+	ShardingStrategy   string `json:"shardingStrategy,omitempty"`
+	SmartJoinAttribute string `json:"smartJoinAttribute,omitempty"`
 }
 
 func (r *CreateCollection) Path() string {
@@ -33,6 +102,23 @@ func (r *CreateCollection) Generate() []byte {
 	return m
 }
 
+// CreateEdgeCollection creates an edge collection in database.
+type CreateEdgeCollection CreateCollection
+
+func (r *CreateEdgeCollection) Path() string {
+	return "/_api/collection"
+}
+
+func (r *CreateEdgeCollection) Method() string {
+	return "POST"
+}
+
+func (r *CreateEdgeCollection) Generate() []byte {
+	c := CreateCollection(*r)
+	c.Type = EdgeCollection
+	return c.Generate()
+}
+
 // DropCollection deletes a collection in database.
 type DropCollection struct {
 	Name string
@@ -68,11 +154,11 @@ func (r *TruncateCollection) Generate() []byte {
 }
 
 type CollectionInfo struct {
-	Id       string `json:"id"`
-	Name     string `json:"name"`
-	IsSystem bool   `json:"isSystem"`
-	Status   int    `json:"status"`
-	Type     int    `json:"type"`
+	Id       string         `json:"id"`
+	Name     string         `json:"name"`
+	IsSystem bool           `json:"isSystem"`
+	Status   int            `json:"status"`
+	Type     CollectionType `json:"type"`
 }
 
 type CollectionInfoList struct {
@@ -83,11 +169,11 @@ type CollectionInfoList struct {
 
 // ListCollections lists all collections from the current DB
 type ListCollections struct {
-	includeSystem bool
+	IncludeSystem bool
 }
 
 func (c *ListCollections) Path() string {
-	return fmt.Sprintf("/_api/collection?excludeSystem=%v", !c.includeSystem)
+	return fmt.Sprintf("/_api/collection?excludeSystem=%v", !c.IncludeSystem)
 }
 
 func (c *ListCollections) Method() string {
@@ -115,3 +201,359 @@ func (c *GetCollectionInfo) Method() string {
 func (c *GetCollectionInfo) Generate() []byte {
 	return nil
 }
+
+// ComputedValue is an attribute value computed by an AQL expression when documents are written.
+type ComputedValue struct {
+	// The name of the computed attribute.
+	Name string `json:"name"`
+	// An AQL expression starting with RETURN, using @doc to access the document.
+	Expression string `json:"expression"`
+	// Overwrite the attribute if the document already has one.
+	Overwrite bool `json:"overwrite"`
+	// The operations computing the value: "insert", "update" and/or "replace". Defaults to all of them.
+	ComputeOn []string `json:"computeOn,omitempty"`
+	// Set the attribute when the expression returns null. Defaults to true.
+	KeepNull *bool `json:"keepNull,omitempty"`
+	// Fail the write operation if the expression produces a warning.
+	FailOnWarning bool `json:"failOnWarning,omitempty"`
+}
+
+// CollectionSchemaRule is the document validation rule of a collection.
+type CollectionSchemaRule struct {
+	// A JSON Schema object.
+	Rule interface{} `json:"rule"`
+	// When the rule is applied: "none", "new", "moderate" or "strict".
+	Level string `json:"level,omitempty"`
+	// The error message returned to the documents breaking the rule.
+	Message string `json:"message,omitempty"`
+	// Only "json" is supported.
+	Type string `json:"type,omitempty"`
+}
+
+// CollectionProperties is a container for data returned by a GetCollectionProperties request.
+type CollectionProperties struct {
+	ID                   string                `json:"id"`
+	Name                 string                `json:"name"`
+	GloballyUniqueID     string                `json:"globallyUniqueId"`
+	IsSystem             bool                  `json:"isSystem"`
+	Status               int                   `json:"status"`
+	Type                 CollectionType        `json:"type"`
+	WaitForSync          bool                  `json:"waitForSync"`
+	CacheEnabled         bool                  `json:"cacheEnabled"`
+	KeyOptions           *KeyOptions           `json:"keyOptions"`
+	Schema               *CollectionSchemaRule `json:"schema"`
+	ComputedValues       []ComputedValue       `json:"computedValues"`
+	SyncByRevision       bool                  `json:"syncByRevision"`
+	NumberOfShards       int                   `json:"numberOfShards"`
+	ShardKeys            []string              `json:"shardKeys"`
+	ReplicationFactor    ReplicationFactor     `json:"replicationFactor"`
+	WriteConcern         int                   `json:"writeConcern"`
+	DistributeShardsLike string                `json:"distributeShardsLike"`
+	ShardingStrategy     string                `json:"shardingStrategy"`
+	SmartJoinAttribute   string                `json:"smartJoinAttribute"`
+}
+
+// GetCollectionProperties retrieves the properties of a collection.
+type GetCollectionProperties struct {
+	Name string
+}
+
+func (r *GetCollectionProperties) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/properties", r.Name)
+}
+
+func (r *GetCollectionProperties) Method() string {
+	return "GET"
+}
+
+func (r *GetCollectionProperties) Generate() []byte {
+	return nil
+}
+
+// SetCollectionProperties changes the properties of a collection. Only the set fields are changed.
+// The result is a CollectionProperties.
+type SetCollectionProperties struct {
+	Name         string
+	WaitForSync  *bool
+	CacheEnabled *bool
+	Schema       *CollectionSchemaRule
+	// Remove the validation rule of the collection.
+	RemoveSchema bool
+	// The computed values replacing the existing ones. An empty non-nil slice removes them.
+	ComputedValues    []ComputedValue
+	ReplicationFactor ReplicationFactor
+	WriteConcern      int
+}
+
+func (r *SetCollectionProperties) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/properties", r.Name)
+}
+
+func (r *SetCollectionProperties) Method() string {
+	return "PUT"
+}
+
+func (r *SetCollectionProperties) Generate() []byte {
+	properties := map[string]interface{}{}
+	if r.WaitForSync != nil {
+		properties["waitForSync"] = *r.WaitForSync
+	}
+	if r.CacheEnabled != nil {
+		properties["cacheEnabled"] = *r.CacheEnabled
+	}
+	if r.Schema != nil {
+		properties["schema"] = r.Schema
+	}
+	if r.RemoveSchema {
+		properties["schema"] = nil
+	}
+	if r.ComputedValues != nil {
+		properties["computedValues"] = r.ComputedValues
+	}
+	if r.ReplicationFactor != 0 {
+		properties["replicationFactor"] = r.ReplicationFactor
+	}
+	if r.WriteConcern != 0 {
+		properties["writeConcern"] = r.WriteConcern
+	}
+	m, _ := json.Marshal(properties)
+	return m
+}
+
+// CollectionCount is a container for data returned by a GetCollectionCount request.
+type CollectionCount struct {
+	CollectionProperties
+	Count int64 `json:"count"`
+}
+
+// GetCollectionCount retrieves the number of documents in a collection.
+type GetCollectionCount struct {
+	Name string
+}
+
+func (r *GetCollectionCount) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/count", r.Name)
+}
+
+func (r *GetCollectionCount) Method() string {
+	return "GET"
+}
+
+func (r *GetCollectionCount) Generate() []byte {
+	return nil
+}
+
+// Figures are the storage statistics of a collection.
+type Figures struct {
+	Indexes struct {
+		Count int64 `json:"count"`
+		Size  int64 `json:"size"`
+	} `json:"indexes"`
+	DocumentsSize        int64   `json:"documentsSize"`
+	CacheInUse           bool    `json:"cacheInUse"`
+	CacheSize            int64   `json:"cacheSize"`
+	CacheUsage           int64   `json:"cacheUsage"`
+	CacheLifeTimeHitRate float64 `json:"cacheLifeTimeHitRate"`
+	// The storage engine statistics, only returned in details mode.
+	Engine json.RawMessage `json:"engine,omitempty"`
+}
+
+// CollectionFigures is a container for data returned by a GetCollectionFigures request.
+type CollectionFigures struct {
+	CollectionProperties
+	Count   int64   `json:"count"`
+	Figures Figures `json:"figures"`
+}
+
+// GetCollectionFigures retrieves the storage statistics of a collection.
+type GetCollectionFigures struct {
+	Name string
+	// Return the storage engine statistics. Can be slow on large collections.
+	Details bool
+}
+
+func (r *GetCollectionFigures) Path() string {
+	path := fmt.Sprintf("/_api/collection/%s/figures", r.Name)
+	if r.Details {
+		path += "?details=true"
+	}
+	return path
+}
+
+func (r *GetCollectionFigures) Method() string {
+	return "GET"
+}
+
+func (r *GetCollectionFigures) Generate() []byte {
+	return nil
+}
+
+// CollectionRevision is a container for data returned by a GetCollectionRevision request.
+type CollectionRevision struct {
+	CollectionProperties
+	Revision string `json:"revision"`
+}
+
+// GetCollectionRevision retrieves the revision of a collection, which changes at each write.
+type GetCollectionRevision struct {
+	Name string
+}
+
+func (r *GetCollectionRevision) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/revision", r.Name)
+}
+
+func (r *GetCollectionRevision) Method() string {
+	return "GET"
+}
+
+func (r *GetCollectionRevision) Generate() []byte {
+	return nil
+}
+
+// CollectionChecksum is a container for data returned by a GetCollectionChecksum request.
+type CollectionChecksum struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"`
+	Revision string `json:"revision"`
+}
+
+// GetCollectionChecksum calculates a checksum of the documents of a collection.
+// By default, only the document keys are included.
+type GetCollectionChecksum struct {
+	Name string
+	// Include the document revisions.
+	WithRevisions bool
+	// Include the document data.
+	WithData bool
+}
+
+func (r *GetCollectionChecksum) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/checksum?withRevisions=%v&withData=%v", r.Name, r.WithRevisions, r.WithData)
+}
+
+func (r *GetCollectionChecksum) Method() string {
+	return "GET"
+}
+
+func (r *GetCollectionChecksum) Generate() []byte {
+	return nil
+}
+
+// RenameCollection renames a collection. The result is a CollectionInfo.
+type RenameCollection struct {
+	Name    string `json:"-"`
+	NewName string `json:"name"`
+}
+
+func (r *RenameCollection) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/rename", r.Name)
+}
+
+func (r *RenameCollection) Method() string {
+	return "PUT"
+}
+
+func (r *RenameCollection) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// CollectionLoad is a container for data returned by a LoadCollection request.
+type CollectionLoad struct {
+	CollectionInfo
+	// Only returned if requested.
+	Count int64 `json:"count"`
+}
+
+// LoadCollection loads a collection into memory. The result is a CollectionLoad.
+// It has no effect on the RocksDB storage engine.
+type LoadCollection struct {
+	Name string `json:"-"`
+	// Return the number of documents, which can be slow.
+	Count bool `json:"count"`
+}
+
+func (r *LoadCollection) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/load", r.Name)
+}
+
+func (r *LoadCollection) Method() string {
+	return "PUT"
+}
+
+func (r *LoadCollection) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// UnloadCollection removes a collection from memory. The result is a CollectionInfo.
+// It has no effect on the RocksDB storage engine.
+type UnloadCollection struct {
+	Name string
+}
+
+func (r *UnloadCollection) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/unload", r.Name)
+}
+
+func (r *UnloadCollection) Method() string {
+	return "PUT"
+}
+
+func (r *UnloadCollection) Generate() []byte {
+	return nil
+}
+
+// CompactCollection compacts the data of a collection. The result is a CollectionInfo.
+type CompactCollection struct {
+	Name string
+}
+
+func (r *CompactCollection) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/compact", r.Name)
+}
+
+func (r *CompactCollection) Method() string {
+	return "PUT"
+}
+
+func (r *CompactCollection) Generate() []byte {
+	return nil
+}
+
+// RecalculateCollectionCount recalculates the document count of a collection,
+// fixing an inconsistent count. The result is a bool.
+type RecalculateCollectionCount struct {
+	Name string
+}
+
+func (r *RecalculateCollectionCount) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/recalculateCount", r.Name)
+}
+
+func (r *RecalculateCollectionCount) Method() string {
+	return "PUT"
+}
+
+func (r *RecalculateCollectionCount) Generate() []byte {
+	return nil
+}
+
+// LoadCollectionIndexes loads the indexes of a collection into memory. The result is a bool.
+type LoadCollectionIndexes struct {
+	Name string
+}
+
+func (r *LoadCollectionIndexes) Path() string {
+	return fmt.Sprintf("/_api/collection/%s/loadIndexesIntoMemory", r.Name)
+}
+
+func (r *LoadCollectionIndexes) Method() string {
+	return "PUT"
+}
+
+func (r *LoadCollectionIndexes) Generate() []byte {
+	return nil
+}
@@ -0,0 +1,131 @@
+package requests_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestSetCollectionProperties runs tests on the SetCollectionProperties request.
+func TestSetCollectionProperties(t *testing.T) {
+	cacheEnabled := true
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request *requests.SetCollectionProperties
+		// Expected results
+		output string
+	}{
+		{
+			description: "no property",
+			request:     &requests.SetCollectionProperties{Name: "nodes"},
+			output:      `{}`,
+		},
+		{
+			description: "schema and cache",
+			request: &requests.SetCollectionProperties{
+				Name:         "nodes",
+				CacheEnabled: &cacheEnabled,
+				Schema: &requests.CollectionSchemaRule{
+					Rule:  map[string]interface{}{"type": "object"},
+					Level: "strict",
+				},
+			},
+			output: `{"cacheEnabled":true,"schema":{"rule":{"type":"object"},"level":"strict"}}`,
+		},
+		{
+			description: "schema and computed values removal",
+			request: &requests.SetCollectionProperties{
+				Name:           "nodes",
+				RemoveSchema:   true,
+				ComputedValues: []requests.ComputedValue{},
+			},
+			output: `{"computedValues":[],"schema":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.request.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.request.Generate())
+			}
+			if tc.request.Path() != "/_api/collection/nodes/properties" {
+				t.Errorf("unexpected path: %s", tc.request.Path())
+			}
+		})
+	}
+}
+
+// TestCreateCollection runs tests on the collection creation requests.
+func TestCreateCollection(t *testing.T) {
+	allowUserKeys := false
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request interface{ Generate() []byte }
+		// Expected results
+		output string
+	}{
+		{
+			description: "document collection",
+			request:     &requests.CreateCollection{Name: "nodes"},
+			output:      `{"name":"nodes"}`,
+		},
+		{
+			description: "key options",
+			request: &requests.CreateCollection{
+				Name:       "nodes",
+				KeyOptions: &requests.KeyOptions{Type: requests.KeyGeneratorPadded, AllowUserKeys: &allowUserKeys},
+			},
+			output: `{"name":"nodes","keyOptions":{"type":"padded","allowUserKeys":false}}`,
+		},
+		{
+			description: "satellite collection",
+			request:     &requests.CreateCollection{Name: "nodes", ReplicationFactor: requests.SatelliteReplication},
+			output:      `{"name":"nodes","replicationFactor":"satellite"}`,
+		},
+		{
+			description: "sharded collection",
+			request: &requests.CreateCollection{
+				Name:              "nodes",
+				NumberOfShards:    3,
+				ReplicationFactor: 2,
+				WriteConcern:      2,
+			},
+			output: `{"name":"nodes","numberOfShards":3,"replicationFactor":2,"writeConcern":2}`,
+		},
+		{
+			description: "edge collection",
+			request:     &requests.CreateEdgeCollection{Name: "links"},
+			output:      `{"name":"links","type":3}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.request.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.request.Generate())
+			}
+		})
+	}
+}
+
+// TestReplicationFactor runs tests on the decoding of the replication factor.
+func TestReplicationFactor(t *testing.T) {
+	properties := &requests.CollectionProperties{}
+	if err := json.Unmarshal([]byte(`{"replicationFactor":"satellite"}`), properties); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if properties.ReplicationFactor != requests.SatelliteReplication {
+		t.Errorf("unexpected replication factor: %d", properties.ReplicationFactor)
+	}
+	if err := json.Unmarshal([]byte(`{"replicationFactor":2}`), properties); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if properties.ReplicationFactor != 2 {
+		t.Errorf("unexpected replication factor: %d", properties.ReplicationFactor)
+	}
+}
@@ -18,3 +18,20 @@ func (r *FollowCursor) Method() string {
 func (r *FollowCursor) Generate() []byte {
 	return nil
 }
+
+// DeleteCursor deletes the given cursor and frees its resources in the database.
+type DeleteCursor struct {
+	Cursor string
+}
+
+func (r *DeleteCursor) Path() string {
+	return fmt.Sprintf("/_api/cursor/%s", r.Cursor)
+}
+
+func (r *DeleteCursor) Method() string {
+	return "DELETE"
+}
+
+func (r *DeleteCursor) Generate() []byte {
+	return nil
+}
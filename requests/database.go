@@ -5,7 +5,19 @@ import (
 	"fmt"
 )
 
-// CurrentDatabase retrieves information on the current database.
+// DatabaseInfo describes a database.
+type DatabaseInfo struct {
+	Name     string `json:"name"`
+	ID       string `json:"id"`
+	Path     string `json:"path"`
+	IsSystem bool   `json:"isSystem"`
+	// The cluster options.
+	Sharding          string            `json:"sharding"`
+	ReplicationFactor ReplicationFactor `json:"replicationFactor"`
+	WriteConcern      int               `json:"writeConcern"`
+}
+
+// CurrentDatabase retrieves information on the current database. The result is a DatabaseInfo.
 type CurrentDatabase struct{}
 
 func (r *CurrentDatabase) Path() string {
@@ -20,11 +32,51 @@ func (r *CurrentDatabase) Generate() []byte {
 	return nil
 }
 
+// ListDatabases lists the names of all the databases. The result can be decoded in a []string.
+// Only available from the _system database.
+type ListDatabases struct{}
+
+func (r *ListDatabases) Path() string {
+	return "/_api/database"
+}
+
+func (r *ListDatabases) Method() string {
+	return "GET"
+}
+
+func (r *ListDatabases) Generate() []byte {
+	return nil
+}
+
+// ListUserDatabases lists the names of the databases accessible by the current user.
+// The result can be decoded in a []string.
+type ListUserDatabases struct{}
+
+func (r *ListUserDatabases) Path() string {
+	return "/_api/database/user"
+}
+
+func (r *ListUserDatabases) Method() string {
+	return "GET"
+}
+
+func (r *ListUserDatabases) Generate() []byte {
+	return nil
+}
+
+// DatabaseOptions are the default options of the collections of a database in a cluster.
+type DatabaseOptions struct {
+	// Either "flexible" (default) or "single", to put all the shards on the same DB-Server.
+	Sharding          string            `json:"sharding,omitempty"`
+	ReplicationFactor ReplicationFactor `json:"replicationFactor,omitempty"`
+	WriteConcern      int               `json:"writeConcern,omitempty"`
+}
+
 // CreateDatabase creates a new database.
 type CreateDatabase struct {
 	Username string                   `json:"username,omitempty"`
 	Name     string                   `json:"name"`
-	Extra    json.RawMessage          `json:"extra,omitempty"`
+	Options  *DatabaseOptions         `json:"options,omitempty"`
 	Passwd   string                   `json:"passwd,omitempty"`
 	Active   bool                     `json:"active,omitempty"`
 	Users    []map[string]interface{} `json:"users,omitempty"`
@@ -0,0 +1,45 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestCreateDatabase runs tests on the CreateDatabase request.
+func TestCreateDatabase(t *testing.T) {
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request *requests.CreateDatabase
+		// Expected results
+		output string
+	}{
+		{
+			description: "no option",
+			request:     &requests.CreateDatabase{Name: "foobar"},
+			output:      `{"name":"foobar"}`,
+		},
+		{
+			description: "cluster options",
+			request: &requests.CreateDatabase{
+				Name: "foobar",
+				Options: &requests.DatabaseOptions{
+					Sharding:          "single",
+					ReplicationFactor: requests.SatelliteReplication,
+					WriteConcern:      2,
+				},
+			},
+			output: `{"name":"foobar","options":{"sharding":"single","replicationFactor":"satellite","writeConcern":2}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.request.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.request.Generate())
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DocumentOptions are the options shared by the document modification requests.
+type DocumentOptions struct {
+	// Wait until the document has been synced to disk.
+	WaitForSync bool
+	// Return the complete new document under the attribute "new" in the result.
+	ReturnNew bool
+	// Return the complete previous document under the attribute "old" in the result.
+	ReturnOld bool
+	// Return an empty object instead of the document metadata.
+	// Ignored by the batch requests, as their results would no longer match the given documents.
+	Silent bool
+}
+
+func (o DocumentOptions) values() url.Values {
+	v := url.Values{}
+	if o.WaitForSync {
+		v.Set("waitForSync", "true")
+	}
+	if o.ReturnNew {
+		v.Set("returnNew", "true")
+	}
+	if o.ReturnOld {
+		v.Set("returnOld", "true")
+	}
+	if o.Silent {
+		v.Set("silent", "true")
+	}
+	return v
+}
+
+// PatchOptions are the options specific to the document update requests.
+type PatchOptions struct {
+	// If false, the attributes set to null in the patch are removed from the
+	// existing document instead of being stored as null. Defaults to true.
+	KeepNull *bool
+	// If false, objects in the patch replace the existing ones instead of
+	// being merged with them. Defaults to true.
+	MergeObjects *bool
+}
+
+func (o PatchOptions) apply(v url.Values) url.Values {
+	if o.KeepNull != nil {
+		v.Set("keepNull", strconv.FormatBool(*o.KeepNull))
+	}
+	if o.MergeObjects != nil {
+		v.Set("mergeObjects", strconv.FormatBool(*o.MergeObjects))
+	}
+	return v
+}
+
+// batchValues returns the options of a batch request, without Silent.
+func (o DocumentOptions) batchValues() url.Values {
+	v := o.values()
+	v.Del("silent")
+	return v
+}
+
+func withQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+	return path + "?" + v.Encode()
+}
+
+// CreateDocument inserts a new document in a collection.
+// The document metadata (_id, _key, _rev) is returned by the database,
+// so running it with the inserted arangolite.Document or arangolite.Edge
+// as the result fills in its generated attributes.
+type CreateDocument struct {
+	Collection string
+	Document   interface{}
+	DocumentOptions
+	// Set to "ignore", "replace", "update" or "conflict" to overwrite
+	// an existing document with the same _key.
+	OverwriteMode string
+	// Only used when OverwriteMode is "update".
+	PatchOptions
+}
+
+func (r *CreateDocument) Path() string {
+	v := r.DocumentOptions.values()
+	if r.OverwriteMode != "" {
+		v.Set("overwriteMode", r.OverwriteMode)
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s", url.PathEscape(r.Collection)), r.PatchOptions.apply(v))
+}
+
+func (r *CreateDocument) Method() string {
+	return "POST"
+}
+
+func (r *CreateDocument) Generate() []byte {
+	m, _ := json.Marshal(r.Document)
+	return m
+}
+
+// GetDocument retrieves a single document.
+type GetDocument struct {
+	Collection string
+	Key        string
+}
+
+func (r *GetDocument) Path() string {
+	return fmt.Sprintf("/_api/document/%s/%s", url.PathEscape(r.Collection), url.PathEscape(r.Key))
+}
+
+func (r *GetDocument) Method() string {
+	return "GET"
+}
+
+func (r *GetDocument) Generate() []byte {
+	return nil
+}
+
+// DocumentExists checks the existence of a document.
+// A missing document returns an error satisfying arangolite.IsErrNotFound.
+type DocumentExists struct {
+	Collection string
+	Key        string
+}
+
+func (r *DocumentExists) Path() string {
+	return fmt.Sprintf("/_api/document/%s/%s", url.PathEscape(r.Collection), url.PathEscape(r.Key))
+}
+
+func (r *DocumentExists) Method() string {
+	return "HEAD"
+}
+
+func (r *DocumentExists) Generate() []byte {
+	return nil
+}
+
+// ReplaceDocument replaces an existing document.
+type ReplaceDocument struct {
+	Collection string
+	Key        string
+	Document   interface{}
+	DocumentOptions
+	// If false, the _rev attribute of the given document is checked against
+	// the stored one. Defaults to true.
+	IgnoreRevs *bool
+}
+
+func (r *ReplaceDocument) Path() string {
+	v := r.DocumentOptions.values()
+	if r.IgnoreRevs != nil {
+		v.Set("ignoreRevs", strconv.FormatBool(*r.IgnoreRevs))
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s/%s", url.PathEscape(r.Collection), url.PathEscape(r.Key)), v)
+}
+
+func (r *ReplaceDocument) Method() string {
+	return "PUT"
+}
+
+func (r *ReplaceDocument) Generate() []byte {
+	m, _ := json.Marshal(r.Document)
+	return m
+}
+
+// UpdateDocument partially updates an existing document.
+type UpdateDocument struct {
+	Collection string
+	Key        string
+	Patch      interface{}
+	DocumentOptions
+	PatchOptions
+	// If false, the _rev attribute of the given patch is checked against
+	// the stored one. Defaults to true.
+	IgnoreRevs *bool
+}
+
+func (r *UpdateDocument) Path() string {
+	v := r.PatchOptions.apply(r.DocumentOptions.values())
+	if r.IgnoreRevs != nil {
+		v.Set("ignoreRevs", strconv.FormatBool(*r.IgnoreRevs))
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s/%s", url.PathEscape(r.Collection), url.PathEscape(r.Key)), v)
+}
+
+func (r *UpdateDocument) Method() string {
+	return "PATCH"
+}
+
+func (r *UpdateDocument) Generate() []byte {
+	m, _ := json.Marshal(r.Patch)
+	return m
+}
+
+// DeleteDocument removes a document.
+type DeleteDocument struct {
+	Collection string
+	Key        string
+	DocumentOptions
+}
+
+func (r *DeleteDocument) Path() string {
+	return withQuery(fmt.Sprintf("/_api/document/%s/%s", url.PathEscape(r.Collection), url.PathEscape(r.Key)), r.DocumentOptions.values())
+}
+
+func (r *DeleteDocument) Method() string {
+	return "DELETE"
+}
+
+func (r *DeleteDocument) Generate() []byte {
+	return nil
+}
+
+// CreateDocuments inserts multiple documents in a collection at once.
+// Documents must be a slice. The database returns one result per document,
+// in the same order, which can be decoded in an arangolite.DocumentResults.
+type CreateDocuments struct {
+	Collection string
+	Documents  interface{}
+	DocumentOptions
+	// Set to "ignore", "replace", "update" or "conflict" to overwrite
+	// existing documents with the same _key.
+	OverwriteMode string
+	// Only used when OverwriteMode is "update".
+	PatchOptions
+}
+
+func (r *CreateDocuments) Path() string {
+	v := r.DocumentOptions.batchValues()
+	if r.OverwriteMode != "" {
+		v.Set("overwriteMode", r.OverwriteMode)
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s", url.PathEscape(r.Collection)), r.PatchOptions.apply(v))
+}
+
+func (r *CreateDocuments) Method() string {
+	return "POST"
+}
+
+func (r *CreateDocuments) Generate() []byte {
+	m, _ := json.Marshal(r.Documents)
+	return m
+}
+
+// ReplaceDocuments replaces multiple documents at once.
+// Documents must be a slice of documents containing their _key.
+type ReplaceDocuments struct {
+	Collection string
+	Documents  interface{}
+	DocumentOptions
+	// If false, the _rev attribute of the given documents is checked against
+	// the stored ones. Defaults to true.
+	IgnoreRevs *bool
+}
+
+func (r *ReplaceDocuments) Path() string {
+	v := r.DocumentOptions.batchValues()
+	if r.IgnoreRevs != nil {
+		v.Set("ignoreRevs", strconv.FormatBool(*r.IgnoreRevs))
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s", url.PathEscape(r.Collection)), v)
+}
+
+func (r *ReplaceDocuments) Method() string {
+	return "PUT"
+}
+
+func (r *ReplaceDocuments) Generate() []byte {
+	m, _ := json.Marshal(r.Documents)
+	return m
+}
+
+// UpdateDocuments partially updates multiple documents at once.
+// Patches must be a slice of patches containing the _key of the document to update.
+type UpdateDocuments struct {
+	Collection string
+	Patches    interface{}
+	DocumentOptions
+	PatchOptions
+	// If false, the _rev attribute of the given patches is checked against
+	// the stored ones. Defaults to true.
+	IgnoreRevs *bool
+}
+
+func (r *UpdateDocuments) Path() string {
+	v := r.PatchOptions.apply(r.DocumentOptions.batchValues())
+	if r.IgnoreRevs != nil {
+		v.Set("ignoreRevs", strconv.FormatBool(*r.IgnoreRevs))
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s", url.PathEscape(r.Collection)), v)
+}
+
+func (r *UpdateDocuments) Method() string {
+	return "PATCH"
+}
+
+func (r *UpdateDocuments) Generate() []byte {
+	m, _ := json.Marshal(r.Patches)
+	return m
+}
+
+// DeleteDocuments removes multiple documents at once.
+// Documents must be a slice of keys or of documents containing their _key.
+type DeleteDocuments struct {
+	Collection string
+	Documents  interface{}
+	DocumentOptions
+	// If false, the _rev attribute of the given documents is checked against
+	// the stored ones. Defaults to true.
+	IgnoreRevs *bool
+}
+
+func (r *DeleteDocuments) Path() string {
+	v := r.DocumentOptions.batchValues()
+	if r.IgnoreRevs != nil {
+		v.Set("ignoreRevs", strconv.FormatBool(*r.IgnoreRevs))
+	}
+	return withQuery(fmt.Sprintf("/_api/document/%s", url.PathEscape(r.Collection)), v)
+}
+
+func (r *DeleteDocuments) Method() string {
+	return "DELETE"
+}
+
+func (r *DeleteDocuments) Generate() []byte {
+	m, _ := json.Marshal(r.Documents)
+	return m
+}
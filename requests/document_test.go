@@ -0,0 +1,64 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestDocumentPath runs tests on the paths of the document requests.
+func TestDocumentPath(t *testing.T) {
+	keepNull := false
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request interface{ Path() string }
+		// Expected results
+		output string
+	}{
+		{
+			description: "create without options",
+			request:     &requests.CreateDocument{Collection: "nodes"},
+			output:      "/_api/document/nodes",
+		},
+		{
+			description: "create with options",
+			request: &requests.CreateDocument{
+				Collection:      "nodes",
+				DocumentOptions: requests.DocumentOptions{ReturnNew: true, WaitForSync: true},
+				OverwriteMode:   "update",
+				PatchOptions:    requests.PatchOptions{KeepNull: &keepNull},
+			},
+			output: "/_api/document/nodes?keepNull=false&overwriteMode=update&returnNew=true&waitForSync=true",
+		},
+		{
+			description: "update with options",
+			request: &requests.UpdateDocument{
+				Collection:      "nodes",
+				Key:             "1234",
+				DocumentOptions: requests.DocumentOptions{ReturnOld: true, Silent: true},
+				PatchOptions:    requests.PatchOptions{MergeObjects: &keepNull},
+			},
+			output: "/_api/document/nodes/1234?mergeObjects=false&returnOld=true&silent=true",
+		},
+		{
+			description: "delete",
+			request:     &requests.DeleteDocument{Collection: "nodes", Key: "1234"},
+			output:      "/_api/document/nodes/1234",
+		},
+		{
+			description: "key with special characters",
+			request:     &requests.GetDocument{Collection: "nodes", Key: "50%:off"},
+			output:      "/_api/document/nodes/50%25:off",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if path := tc.request.Path(); path != tc.output {
+				t.Errorf("unexpected path. Expected %s, got %s", tc.output, path)
+			}
+		})
+	}
+}
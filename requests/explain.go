@@ -0,0 +1,178 @@
+package requests
+
+import "encoding/json"
+
+// ExplainAQL explains an AQL query, returning its execution plan without running it.
+type ExplainAQL struct {
+	Query *AQL
+	// Return all the plans generated by the optimizer instead of the optimal one.
+	AllPlans bool
+	// The maximum number of plans generated by the optimizer.
+	MaxNumberOfPlans int
+}
+
+func (r *ExplainAQL) Path() string {
+	return "/_api/explain"
+}
+
+func (r *ExplainAQL) Method() string {
+	return "POST"
+}
+
+func (r *ExplainAQL) Generate() []byte {
+	type ExplainOptionsFmt struct {
+		AllPlans         bool          `json:"allPlans,omitempty"`
+		MaxNumberOfPlans int           `json:"maxNumberOfPlans,omitempty"`
+		Optimizer        *aqlOptimizer `json:"optimizer,omitempty"`
+	}
+	type ExplainFmt struct {
+		Query    string                 `json:"query"`
+		BindVars map[string]interface{} `json:"bindVars,omitempty"`
+		Options  ExplainOptionsFmt      `json:"options"`
+	}
+
+	explainFmt := &ExplainFmt{
+		Query:    r.Query.query,
+		BindVars: r.Query.bindVars,
+		Options:  ExplainOptionsFmt{AllPlans: r.AllPlans, MaxNumberOfPlans: r.MaxNumberOfPlans},
+	}
+	if r.Query.options != nil {
+		explainFmt.Options.Optimizer = r.Query.options.Optimizer
+	}
+
+	m, _ := json.Marshal(explainFmt)
+	return m
+}
+
+// ExplainResult is the result of an ExplainAQL request.
+type ExplainResult struct {
+	// The optimal plan. Not set if AllPlans is enabled.
+	Plan *ExplainPlan `json:"plan,omitempty"`
+	// All the generated plans, if AllPlans is enabled.
+	Plans     []ExplainPlan    `json:"plans,omitempty"`
+	Warnings  []ExplainWarning `json:"warnings"`
+	Cacheable bool             `json:"cacheable"`
+	Stats     ExplainStats     `json:"stats"`
+}
+
+// ExplainWarning is a warning raised by the optimizer about an explained query.
+type ExplainWarning struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ExplainStats are the optimizer statistics of an explained query.
+type ExplainStats struct {
+	RulesExecuted int     `json:"rulesExecuted"`
+	RulesSkipped  int     `json:"rulesSkipped"`
+	PlansCreated  int     `json:"plansCreated"`
+	ExecutionTime float64 `json:"executionTime"`
+}
+
+// ExplainPlan is a query execution plan.
+type ExplainPlan struct {
+	Nodes []PlanNode `json:"nodes"`
+	// The optimizer rules applied to the plan.
+	Rules               []string         `json:"rules"`
+	Collections         []PlanCollection `json:"collections"`
+	Variables           []PlanVariable   `json:"variables"`
+	EstimatedCost       float64          `json:"estimatedCost"`
+	EstimatedNrItems    int64            `json:"estimatedNrItems"`
+	IsModificationQuery bool             `json:"isModificationQuery"`
+}
+
+// PlanNode is a node of a query execution plan. Only the most common attributes are decoded.
+type PlanNode struct {
+	ID               int     `json:"id"`
+	Type             string  `json:"type"`
+	Dependencies     []int   `json:"dependencies"`
+	EstimatedCost    float64 `json:"estimatedCost"`
+	EstimatedNrItems int64   `json:"estimatedNrItems"`
+	// The collection used by the collection, index and modification nodes.
+	Collection string `json:"collection,omitempty"`
+	// The indexes used by an IndexNode.
+	Indexes []PlanIndex `json:"indexes,omitempty"`
+	// The nodes of a SubqueryNode.
+	Subquery *ExplainPlan `json:"subquery,omitempty"`
+}
+
+// PlanIndex is an index used in a query execution plan.
+type PlanIndex struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Type                string   `json:"type"`
+	Fields              []string `json:"fields"`
+	Unique              bool     `json:"unique"`
+	Sparse              bool     `json:"sparse"`
+	SelectivityEstimate float64  `json:"selectivityEstimate"`
+}
+
+// PlanCollection is a collection used in a query execution plan.
+type PlanCollection struct {
+	Name string `json:"name"`
+	// Either "read" or "write".
+	Type string `json:"type"`
+}
+
+// PlanVariable is a variable used in a query execution plan.
+type PlanVariable struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// FullCollectionScans returns the nodes of the plan, subqueries included,
+// iterating over a whole collection without using an index.
+func (p *ExplainPlan) FullCollectionScans() []PlanNode {
+	scans := []PlanNode{}
+	if p == nil {
+		return scans
+	}
+	for _, node := range p.Nodes {
+		if node.Type == "EnumerateCollectionNode" {
+			scans = append(scans, node)
+		}
+		if node.Subquery != nil {
+			scans = append(scans, node.Subquery.FullCollectionScans()...)
+		}
+	}
+	return scans
+}
+
+// FullCollectionScans returns the full collection scans of the optimal plan,
+// or of all the plans if AllPlans is enabled.
+func (r *ExplainResult) FullCollectionScans() []PlanNode {
+	scans := r.Plan.FullCollectionScans()
+	for i := range r.Plans {
+		scans = append(scans, r.Plans[i].FullCollectionScans()...)
+	}
+	return scans
+}
+
+// ParseAQL parses an AQL query, checking its syntax without running it.
+type ParseAQL struct {
+	Query *AQL
+}
+
+func (r *ParseAQL) Path() string {
+	return "/_api/query"
+}
+
+func (r *ParseAQL) Method() string {
+	return "POST"
+}
+
+func (r *ParseAQL) Generate() []byte {
+	m, _ := json.Marshal(map[string]string{"query": r.Query.query})
+	return m
+}
+
+// ParseResult is the result of a ParseAQL request.
+type ParseResult struct {
+	Parsed bool `json:"parsed"`
+	// The collections used in the query.
+	Collections []string `json:"collections"`
+	// The bind parameters used in the query.
+	BindVars []string `json:"bindVars"`
+	// The abstract syntax tree of the query.
+	AST []json.RawMessage `json:"ast"`
+}
@@ -0,0 +1,75 @@
+package requests_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestExplainAQL runs tests on the ExplainAQL request.
+func TestExplainAQL(t *testing.T) {
+	q := requests.NewAQL("FOR x IN documents FILTER x.foo == @foo RETURN x").
+		Bind("foo", "bar").
+		OptimizerRules("-all")
+
+	output := `{"query":"FOR x IN documents FILTER x.foo == @foo RETURN x","bindVars":{"foo":"bar"},` +
+		`"options":{"allPlans":true,"optimizer":{"rules":["-all"]}}}`
+	explain := &requests.ExplainAQL{Query: q, AllPlans: true}
+	if string(explain.Generate()) != output {
+		t.Errorf("unexpected output. Expected %s, got %s", output, explain.Generate())
+	}
+}
+
+// TestFullCollectionScans runs tests on the detection of full collection scans.
+func TestFullCollectionScans(t *testing.T) {
+	raw := `{
+		"plan": {
+			"nodes": [
+				{"type": "SingletonNode", "id": 1, "dependencies": []},
+				{"type": "IndexNode", "id": 2, "dependencies": [1], "collection": "users",
+				 "indexes": [{"id": "123", "type": "persistent", "fields": ["name"]}]},
+				{"type": "SubqueryNode", "id": 3, "dependencies": [2], "subquery": {"nodes": [
+					{"type": "SingletonNode", "id": 4, "dependencies": []},
+					{"type": "EnumerateCollectionNode", "id": 5, "dependencies": [4], "collection": "orders"}
+				]}},
+				{"type": "ReturnNode", "id": 6, "dependencies": [3]}
+			],
+			"rules": ["use-indexes"],
+			"collections": [{"name": "users", "type": "read"}, {"name": "orders", "type": "read"}],
+			"estimatedCost": 12.5
+		},
+		"cacheable": true,
+		"warnings": []
+	}`
+
+	result := requests.ExplainResult{}
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	scans := result.Plan.FullCollectionScans()
+	if len(scans) != 1 || scans[0].Collection != "orders" {
+		t.Errorf("unexpected full collection scans: %+v", scans)
+	}
+
+	if scans := result.FullCollectionScans(); len(scans) != 1 || scans[0].Collection != "orders" {
+		t.Errorf("unexpected full collection scans of the result: %+v", scans)
+	}
+
+	// With AllPlans, the plans are returned in Plans and Plan is not set.
+	allPlans := requests.ExplainResult{}
+	raw = `{"plans": [
+		{"nodes": [{"type": "EnumerateCollectionNode", "id": 2, "collection": "users"}]},
+		{"nodes": [{"type": "EnumerateCollectionNode", "id": 2, "collection": "orders"}]}
+	]}`
+	if err := json.Unmarshal([]byte(raw), &allPlans); err != nil {
+		t.Fatal(err)
+	}
+	if scans := allPlans.Plan.FullCollectionScans(); len(scans) != 0 {
+		t.Errorf("unexpected full collection scans of a missing plan: %+v", scans)
+	}
+	if scans := allPlans.FullCollectionScans(); len(scans) != 2 {
+		t.Errorf("unexpected full collection scans of all the plans: %+v", scans)
+	}
+}
@@ -4,6 +4,7 @@ import "encoding/json"
 import "fmt"
 
 // CreateHashIndex creates a hash index in database.
+// Recent servers treat hash indexes as persistent indexes: prefer CreatePersistentIndex.
 type CreateHashIndex struct {
 	CollectionName string   `json:"-"`
 	Fields         []string `json:"fields,omitempty"`
@@ -13,7 +14,7 @@ type CreateHashIndex struct {
 }
 
 func (r *CreateHashIndex) Path() string {
-	return fmt.Sprintf("/_api/index?collection=%s", r.CollectionName)
+	return indexPath(r.CollectionName)
 }
 
 func (r *CreateHashIndex) Method() string {
@@ -25,3 +26,362 @@ func (r *CreateHashIndex) Generate() []byte {
 	m, _ := json.Marshal(r)
 	return m
 }
+
+// IndexOptions are the options shared by the index creation requests.
+type IndexOptions struct {
+	// The index name. Generated by the database if empty.
+	Name string `json:"name,omitempty"`
+	// Create the index without holding an exclusive lock on the collection.
+	InBackground bool `json:"inBackground,omitempty"`
+}
+
+// CreatePersistentIndex creates a persistent index in database.
+type CreatePersistentIndex struct {
+	CollectionName string   `json:"-"`
+	Fields         []string `json:"fields"`
+	Unique         bool     `json:"unique,omitempty"`
+	Sparse         bool     `json:"sparse,omitempty"`
+	// Index a value only once per document for array fields. Defaults to true.
+	Deduplicate *bool `json:"deduplicate,omitempty"`
+	// Maintain selectivity estimates. Defaults to true.
+	Estimates    *bool    `json:"estimates,omitempty"`
+	CacheEnabled bool     `json:"cacheEnabled,omitempty"`
+	StoredValues []string `json:"storedValues,omitempty"`
+	IndexOptions
+}
+
+func (r *CreatePersistentIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreatePersistentIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreatePersistentIndex) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreatePersistentIndex
+	}{"persistent", r})
+	return m
+}
+
+// CreateSkiplistIndex creates a skiplist index in database.
+// Recent servers treat skiplist indexes as persistent indexes: prefer CreatePersistentIndex.
+type CreateSkiplistIndex struct {
+	CollectionName string   `json:"-"`
+	Fields         []string `json:"fields"`
+	Unique         bool     `json:"unique,omitempty"`
+	Sparse         bool     `json:"sparse,omitempty"`
+	// Index a value only once per document for array fields. Defaults to true.
+	Deduplicate *bool `json:"deduplicate,omitempty"`
+	IndexOptions
+}
+
+func (r *CreateSkiplistIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreateSkiplistIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreateSkiplistIndex) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreateSkiplistIndex
+	}{"skiplist", r})
+	return m
+}
+
+// CreateGeoIndex creates a geo index in database.
+// Fields is either a single field containing a [latitude, longitude] pair
+// (or a GeoJSON object if GeoJSON is set) or two fields containing the latitude and the longitude.
+type CreateGeoIndex struct {
+	CollectionName string   `json:"-"`
+	Fields         []string `json:"fields"`
+	// Read the field as a GeoJSON object or a [longitude, latitude] pair.
+	GeoJSON bool `json:"geoJson,omitempty"`
+	// Use the polygon semantics of the servers prior to 3.10.
+	LegacyPolygons bool `json:"legacyPolygons,omitempty"`
+	IndexOptions
+}
+
+func (r *CreateGeoIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreateGeoIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreateGeoIndex) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreateGeoIndex
+	}{"geo", r})
+	return m
+}
+
+// CreateFulltextIndex creates a fulltext index in database.
+type CreateFulltextIndex struct {
+	CollectionName string   `json:"-"`
+	Fields         []string `json:"fields"`
+	// The minimum length of the indexed words.
+	MinLength int `json:"minLength,omitempty"`
+	IndexOptions
+}
+
+func (r *CreateFulltextIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreateFulltextIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreateFulltextIndex) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreateFulltextIndex
+	}{"fulltext", r})
+	return m
+}
+
+// CreateTTLIndex creates a TTL index in database, removing the documents
+// once the date stored in the single given field is expired.
+type CreateTTLIndex struct {
+	CollectionName string   `json:"-"`
+	Fields         []string `json:"fields"`
+	// The number of seconds after the field date when the document expires.
+	ExpireAfter int `json:"expireAfter"`
+	IndexOptions
+}
+
+func (r *CreateTTLIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreateTTLIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreateTTLIndex) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreateTTLIndex
+	}{"ttl", r})
+	return m
+}
+
+// InvertedIndexField is a field of an inverted index.
+type InvertedIndexField struct {
+	Name               string               `json:"name"`
+	Analyzer           string               `json:"analyzer,omitempty"`
+	Features           []string             `json:"features,omitempty"`
+	IncludeAllFields   bool                 `json:"includeAllFields,omitempty"`
+	SearchField        bool                 `json:"searchField,omitempty"`
+	TrackListPositions bool                 `json:"trackListPositions,omitempty"`
+	Nested             []InvertedIndexField `json:"nested,omitempty"`
+}
+
+// PrimarySortField is a field of a primary sort order.
+type PrimarySortField struct {
+	Field string `json:"field"`
+	// Either "asc" or "desc".
+	Direction string `json:"direction"`
+}
+
+// PrimarySort is the order in which the data is stored in an inverted index or a view.
+type PrimarySort struct {
+	Fields []PrimarySortField `json:"fields"`
+	// Either "lz4" or "none".
+	Compression string `json:"compression,omitempty"`
+}
+
+// CreateInvertedIndex creates an inverted index in database, usable by search-alias views.
+type CreateInvertedIndex struct {
+	CollectionName     string               `json:"-"`
+	Fields             []InvertedIndexField `json:"fields"`
+	Analyzer           string               `json:"analyzer,omitempty"`
+	Features           []string             `json:"features,omitempty"`
+	IncludeAllFields   bool                 `json:"includeAllFields,omitempty"`
+	SearchField        bool                 `json:"searchField,omitempty"`
+	TrackListPositions bool                 `json:"trackListPositions,omitempty"`
+	PrimarySort        *PrimarySort         `json:"primarySort,omitempty"`
+	StoredValues       []json.RawMessage    `json:"storedValues,omitempty"`
+	Parallelism        int                  `json:"parallelism,omitempty"`
+	IndexOptions
+}
+
+func (r *CreateInvertedIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreateInvertedIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreateInvertedIndex) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreateInvertedIndex
+	}{"inverted", r})
+	return m
+}
+
+// CreateMDIndex creates a multi-dimensional index in database.
+type CreateMDIndex struct {
+	CollectionName string   `json:"-"`
+	Fields         []string `json:"fields"`
+	// The type of the indexed values. Only "double" is supported, which is the default.
+	FieldValueTypes string `json:"fieldValueTypes"`
+	// Creates a prefixed multi-dimensional index ("mdi-prefixed") using these fields for equality lookups.
+	PrefixFields []string `json:"prefixFields,omitempty"`
+	Unique       bool     `json:"unique,omitempty"`
+	Sparse       bool     `json:"sparse,omitempty"`
+	StoredValues []string `json:"storedValues,omitempty"`
+	// Maintain selectivity estimates. Defaults to true.
+	Estimates *bool `json:"estimates,omitempty"`
+	IndexOptions
+}
+
+func (r *CreateMDIndex) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *CreateMDIndex) Method() string {
+	return "POST"
+}
+
+func (r *CreateMDIndex) Generate() []byte {
+	indexType := "mdi"
+	if len(r.PrefixFields) > 0 {
+		indexType = "mdi-prefixed"
+	}
+	index := *r
+	if index.FieldValueTypes == "" {
+		index.FieldValueTypes = "double"
+	}
+	m, _ := json.Marshal(struct {
+		Type string `json:"type"`
+		*CreateMDIndex
+	}{indexType, &index})
+	return m
+}
+
+func indexPath(collectionName string) string {
+	return fmt.Sprintf("/_api/index?collection=%s", collectionName)
+}
+
+// IndexInfo describes an index.
+type IndexInfo struct {
+	// The index handle. Format: ':collection/:id'
+	ID                  string      `json:"id"`
+	Name                string      `json:"name"`
+	Type                string      `json:"type"`
+	Fields              IndexFields `json:"fields"`
+	SelectivityEstimate float64     `json:"selectivityEstimate"`
+	Unique              bool        `json:"unique"`
+	Sparse              bool        `json:"sparse"`
+	Deduplicate         bool        `json:"deduplicate"`
+	Estimates           bool        `json:"estimates"`
+	InBackground        bool        `json:"inBackground"`
+	CacheEnabled        bool        `json:"cacheEnabled"`
+	// Set for TTL indexes.
+	ExpireAfter int `json:"expireAfter"`
+	// Set for geo indexes.
+	GeoJSON bool `json:"geoJson"`
+	// Set for fulltext indexes.
+	MinLength int `json:"minLength"`
+	// Set when the index has just been created by a creation request.
+	IsNewlyCreated bool `json:"isNewlyCreated"`
+}
+
+// IndexFields are the fields of an index.
+type IndexFields []string
+
+// UnmarshalJSON decodes the fields of any index type. Inverted indexes
+// describe their fields as objects, of which only the name is kept.
+func (f *IndexFields) UnmarshalJSON(b []byte) error {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	fields := make(IndexFields, len(raw))
+	for i, r := range raw {
+		if len(r) > 0 && r[0] == '{' {
+			field := struct {
+				Name string `json:"name"`
+			}{}
+			if err := json.Unmarshal(r, &field); err != nil {
+				return err
+			}
+			fields[i] = field.Name
+			continue
+		}
+		if err := json.Unmarshal(r, &fields[i]); err != nil {
+			return err
+		}
+	}
+	*f = fields
+	return nil
+}
+
+// IndexList is a container for data returned by a ListIndexes request.
+type IndexList struct {
+	Indexes []IndexInfo `json:"indexes"`
+}
+
+// ListIndexes lists the indexes of a collection.
+type ListIndexes struct {
+	CollectionName string
+}
+
+func (r *ListIndexes) Path() string {
+	return indexPath(r.CollectionName)
+}
+
+func (r *ListIndexes) Method() string {
+	return "GET"
+}
+
+func (r *ListIndexes) Generate() []byte {
+	return nil
+}
+
+// GetIndex retrieves an index.
+type GetIndex struct {
+	// The index handle. Format: ':collection/:id'
+	ID string
+}
+
+func (r *GetIndex) Path() string {
+	return fmt.Sprintf("/_api/index/%s", r.ID)
+}
+
+func (r *GetIndex) Method() string {
+	return "GET"
+}
+
+func (r *GetIndex) Generate() []byte {
+	return nil
+}
+
+// DropIndex deletes an index.
+type DropIndex struct {
+	// The index handle. Format: ':collection/:id'
+	ID string
+}
+
+func (r *DropIndex) Path() string {
+	return fmt.Sprintf("/_api/index/%s", r.ID)
+}
+
+func (r *DropIndex) Method() string {
+	return "DELETE"
+}
+
+func (r *DropIndex) Generate() []byte {
+	return nil
+}
@@ -0,0 +1,86 @@
+package requests_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestCreateIndex runs tests on the index creation requests.
+func TestCreateIndex(t *testing.T) {
+	deduplicate := false
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request interface{ Generate() []byte }
+		// Expected results
+		output string
+	}{
+		{
+			description: "persistent index",
+			request: &requests.CreatePersistentIndex{
+				CollectionName: "nodes",
+				Fields:         []string{"a", "b"},
+				Unique:         true,
+				Deduplicate:    &deduplicate,
+				IndexOptions:   requests.IndexOptions{Name: "byAB", InBackground: true},
+			},
+			output: `{"type":"persistent","fields":["a","b"],"unique":true,"deduplicate":false,"name":"byAB","inBackground":true}`,
+		},
+		{
+			description: "geo index",
+			request:     &requests.CreateGeoIndex{Fields: []string{"location"}, GeoJSON: true},
+			output:      `{"type":"geo","fields":["location"],"geoJson":true}`,
+		},
+		{
+			description: "ttl index",
+			request:     &requests.CreateTTLIndex{Fields: []string{"expiresAt"}},
+			output:      `{"type":"ttl","fields":["expiresAt"],"expireAfter":0}`,
+		},
+		{
+			description: "inverted index",
+			request: &requests.CreateInvertedIndex{
+				Fields:   []requests.InvertedIndexField{{Name: "title", Analyzer: "text_en"}},
+				Analyzer: "identity",
+			},
+			output: `{"type":"inverted","fields":[{"name":"title","analyzer":"text_en"}],"analyzer":"identity"}`,
+		},
+		{
+			description: "multi-dimensional index",
+			request:     &requests.CreateMDIndex{Fields: []string{"x", "y"}},
+			output:      `{"type":"mdi","fields":["x","y"],"fieldValueTypes":"double"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.request.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.request.Generate())
+			}
+		})
+	}
+}
+
+// TestIndexList runs tests on the decoding of the ListIndexes result.
+func TestIndexList(t *testing.T) {
+	raw := `{"indexes": [
+		{"id": "nodes/0", "name": "primary", "type": "primary", "fields": ["_key"], "unique": true, "selectivityEstimate": 1},
+		{"id": "nodes/12", "name": "search", "type": "inverted", "fields": [{"name": "title", "analyzer": "text_en"}]}
+	]}`
+
+	list := requests.IndexList{}
+	if err := json.Unmarshal([]byte(raw), &list); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []requests.IndexInfo{
+		{ID: "nodes/0", Name: "primary", Type: "primary", Fields: requests.IndexFields{"_key"}, Unique: true, SelectivityEstimate: 1},
+		{ID: "nodes/12", Name: "search", Type: "inverted", Fields: requests.IndexFields{"title"}},
+	}
+	if !reflect.DeepEqual(list.Indexes, expected) {
+		t.Errorf("unexpected indexes. Expected %+v, got %+v", expected, list.Indexes)
+	}
+}
@@ -0,0 +1,157 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RunningQuery is a query currently running, or a slow query, in the database.
+type RunningQuery struct {
+	ID       string                 `json:"id"`
+	Database string                 `json:"database"`
+	User     string                 `json:"user"`
+	Query    string                 `json:"query"`
+	BindVars map[string]interface{} `json:"bindVars"`
+	// The query start date, in ISO 8601 format.
+	Started string `json:"started"`
+	// The query run time, in seconds.
+	RunTime float64 `json:"runTime"`
+	// The maximum memory usage of the query, in bytes.
+	PeakMemoryUsage int64 `json:"peakMemoryUsage"`
+	// The query execution state, e.g. "executing" or "finished".
+	State  string `json:"state"`
+	Stream bool   `json:"stream"`
+}
+
+// GetRunningQueries lists the queries currently running. The result can be decoded in a []RunningQuery.
+type GetRunningQueries struct {
+	// List the queries of all databases. Only allowed on the _system database.
+	All bool
+}
+
+func (r *GetRunningQueries) Path() string {
+	return withAll("/_api/query/current", r.All)
+}
+
+func (r *GetRunningQueries) Method() string {
+	return "GET"
+}
+
+func (r *GetRunningQueries) Generate() []byte {
+	return nil
+}
+
+// GetSlowQueries lists the slow queries. The result can be decoded in a []RunningQuery.
+type GetSlowQueries struct {
+	// List the queries of all databases. Only allowed on the _system database.
+	All bool
+}
+
+func (r *GetSlowQueries) Path() string {
+	return withAll("/_api/query/slow", r.All)
+}
+
+func (r *GetSlowQueries) Method() string {
+	return "GET"
+}
+
+func (r *GetSlowQueries) Generate() []byte {
+	return nil
+}
+
+// ClearSlowQueries clears the list of slow queries.
+type ClearSlowQueries struct {
+	// Clear the queries of all databases. Only allowed on the _system database.
+	All bool
+}
+
+func (r *ClearSlowQueries) Path() string {
+	return withAll("/_api/query/slow", r.All)
+}
+
+func (r *ClearSlowQueries) Method() string {
+	return "DELETE"
+}
+
+func (r *ClearSlowQueries) Generate() []byte {
+	return nil
+}
+
+// KillQuery kills a running query.
+type KillQuery struct {
+	ID string
+	// Kill a query of any database. Only allowed on the _system database.
+	All bool
+}
+
+func (r *KillQuery) Path() string {
+	return withAll(fmt.Sprintf("/_api/query/%s", r.ID), r.All)
+}
+
+func (r *KillQuery) Method() string {
+	return "DELETE"
+}
+
+func (r *KillQuery) Generate() []byte {
+	return nil
+}
+
+func withAll(path string, all bool) string {
+	if all {
+		path += "?all=true"
+	}
+	return path
+}
+
+// QueryTrackingProperties are the query tracking properties of the database.
+type QueryTrackingProperties struct {
+	Enabled          bool `json:"enabled"`
+	TrackSlowQueries bool `json:"trackSlowQueries"`
+	TrackBindVars    bool `json:"trackBindVars"`
+	MaxSlowQueries   int  `json:"maxSlowQueries"`
+	// The run time above which a query is considered slow, in seconds.
+	SlowQueryThreshold float64 `json:"slowQueryThreshold"`
+	// The run time above which a streaming query is considered slow, in seconds.
+	SlowStreamingQueryThreshold float64 `json:"slowStreamingQueryThreshold"`
+	MaxQueryStringLength        int     `json:"maxQueryStringLength"`
+}
+
+// GetQueryTracking retrieves the query tracking properties.
+// The result can be decoded in a QueryTrackingProperties.
+type GetQueryTracking struct{}
+
+func (r *GetQueryTracking) Path() string {
+	return "/_api/query/properties"
+}
+
+func (r *GetQueryTracking) Method() string {
+	return "GET"
+}
+
+func (r *GetQueryTracking) Generate() []byte {
+	return nil
+}
+
+// SetQueryTracking changes the query tracking properties. Nil values are left unchanged.
+type SetQueryTracking struct {
+	Enabled                     *bool    `json:"enabled,omitempty"`
+	TrackSlowQueries            *bool    `json:"trackSlowQueries,omitempty"`
+	TrackBindVars               *bool    `json:"trackBindVars,omitempty"`
+	MaxSlowQueries              *int     `json:"maxSlowQueries,omitempty"`
+	SlowQueryThreshold          *float64 `json:"slowQueryThreshold,omitempty"`
+	SlowStreamingQueryThreshold *float64 `json:"slowStreamingQueryThreshold,omitempty"`
+	MaxQueryStringLength        *int     `json:"maxQueryStringLength,omitempty"`
+}
+
+func (r *SetQueryTracking) Path() string {
+	return "/_api/query/properties"
+}
+
+func (r *SetQueryTracking) Method() string {
+	return "PUT"
+}
+
+func (r *SetQueryTracking) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
@@ -0,0 +1,103 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestQueryManagement runs tests on the query management requests.
+func TestQueryManagement(t *testing.T) {
+	enabled := true
+	maxSlowQueries := 50
+	threshold := 2.5
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request interface {
+			Path() string
+			Method() string
+			Generate() []byte
+		}
+		// Expected results
+		path   string
+		method string
+		output string
+	}{
+		{
+			description: "running queries",
+			request:     &requests.GetRunningQueries{},
+			path:        "/_api/query/current",
+			method:      "GET",
+		},
+		{
+			description: "running queries of all databases",
+			request:     &requests.GetRunningQueries{All: true},
+			path:        "/_api/query/current?all=true",
+			method:      "GET",
+		},
+		{
+			description: "slow queries of all databases",
+			request:     &requests.GetSlowQueries{All: true},
+			path:        "/_api/query/slow?all=true",
+			method:      "GET",
+		},
+		{
+			description: "clear slow queries",
+			request:     &requests.ClearSlowQueries{},
+			path:        "/_api/query/slow",
+			method:      "DELETE",
+		},
+		{
+			description: "kill query",
+			request:     &requests.KillQuery{ID: "1234"},
+			path:        "/_api/query/1234",
+			method:      "DELETE",
+		},
+		{
+			description: "kill query of another database",
+			request:     &requests.KillQuery{ID: "1234", All: true},
+			path:        "/_api/query/1234?all=true",
+			method:      "DELETE",
+		},
+		{
+			description: "get query tracking",
+			request:     &requests.GetQueryTracking{},
+			path:        "/_api/query/properties",
+			method:      "GET",
+		},
+		{
+			description: "set query tracking",
+			request: &requests.SetQueryTracking{
+				Enabled:            &enabled,
+				MaxSlowQueries:     &maxSlowQueries,
+				SlowQueryThreshold: &threshold,
+			},
+			path:   "/_api/query/properties",
+			method: "PUT",
+			output: `{"enabled":true,"maxSlowQueries":50,"slowQueryThreshold":2.5}`,
+		},
+		{
+			description: "set query tracking without changes",
+			request:     &requests.SetQueryTracking{},
+			path:        "/_api/query/properties",
+			method:      "PUT",
+			output:      `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if path := tc.request.Path(); path != tc.path {
+				t.Errorf("unexpected path. Expected %s, got %s", tc.path, path)
+			}
+			if method := tc.request.Method(); method != tc.method {
+				t.Errorf("unexpected method. Expected %s, got %s", tc.method, method)
+			}
+			if output := string(tc.request.Generate()); output != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, output)
+			}
+		})
+	}
+}
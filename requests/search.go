@@ -0,0 +1,238 @@
+package requests
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Search builds an ArangoSearch query on a view, passing every value as a bind parameter.
+//
+// e.g.
+//
+//	NewSearch("nodesView").
+//		Where(Analyzer(Phrase("description", "fast server"), "text_en")).
+//		SortBM25().
+//		Limit(0, 10).
+//		AQL()
+//
+// The generated bind parameters are named "search0", "search1"...
+// The view documents are bound to the variable "d".
+type Search struct {
+	view   string
+	where  SearchExpr
+	sorts  []searchSort
+	offset int
+	limit  int
+}
+
+type searchSort struct {
+	expr func(b *searchBinder) string
+	desc bool
+}
+
+// NewSearch returns a new Search on the given view.
+func NewSearch(view string) *Search {
+	return &Search{view: view}
+}
+
+// Where sets the search condition.
+func (s *Search) Where(expr SearchExpr) *Search {
+	s.where = expr
+	return s
+}
+
+// SortBM25 sorts the documents by their Okapi BM25 score, most relevant first.
+func (s *Search) SortBM25() *Search {
+	s.sorts = append(s.sorts, searchSort{
+		expr: func(b *searchBinder) string { return "BM25(d)" },
+		desc: true,
+	})
+	return s
+}
+
+// SortTFIDF sorts the documents by their TF-IDF score, most relevant first.
+func (s *Search) SortTFIDF(normalize bool) *Search {
+	s.sorts = append(s.sorts, searchSort{
+		expr: func(b *searchBinder) string { return fmt.Sprintf("TFIDF(d, %s)", b.value(normalize)) },
+		desc: true,
+	})
+	return s
+}
+
+// Sort sorts the documents by an attribute.
+func (s *Search) Sort(attribute string, desc bool) *Search {
+	s.sorts = append(s.sorts, searchSort{
+		expr: func(b *searchBinder) string { return b.attribute(attribute) },
+		desc: desc,
+	})
+	return s
+}
+
+// Limit sets the number of documents to skip and the maximum number of documents to return.
+func (s *Search) Limit(offset, count int) *Search {
+	s.offset = offset
+	s.limit = count
+	return s
+}
+
+// AQL returns the AQL query of the search.
+func (s *Search) AQL() *AQL {
+	b := &searchBinder{bindVars: map[string]interface{}{"@view": s.view}}
+	buf := bytes.NewBufferString("FOR d IN @@view")
+
+	if s.where != nil {
+		buf.WriteString(" SEARCH ")
+		buf.WriteString(s.where.build(b))
+	}
+	if len(s.sorts) > 0 {
+		sorts := make([]string, len(s.sorts))
+		for i, sort := range s.sorts {
+			sorts[i] = sort.expr(b)
+			if sort.desc {
+				sorts[i] += " DESC"
+			}
+		}
+		buf.WriteString(" SORT ")
+		buf.WriteString(strings.Join(sorts, ", "))
+	}
+	if s.limit > 0 {
+		fmt.Fprintf(buf, " LIMIT %s, %s", b.value(s.offset), b.value(s.limit))
+	}
+	buf.WriteString(" RETURN d")
+
+	return &AQL{query: buf.String(), bindVars: b.bindVars}
+}
+
+// searchBinder names the bind parameters of a search.
+type searchBinder struct {
+	bindVars map[string]interface{}
+}
+
+// value binds a value and returns its parameter.
+func (b *searchBinder) value(v interface{}) string {
+	name := fmt.Sprintf("search%d", len(b.bindVars)-1)
+	b.bindVars[name] = v
+	return "@" + name
+}
+
+// attribute binds an attribute path, e.g. "address.city", and returns its access expression.
+func (b *searchBinder) attribute(path string) string {
+	return "d." + b.value(strings.Split(path, "."))
+}
+
+// SearchExpr is a condition of a SEARCH operation.
+type SearchExpr interface {
+	build(b *searchBinder) string
+}
+
+type searchExpr func(b *searchBinder) string
+
+func (e searchExpr) build(b *searchBinder) string {
+	return e(b)
+}
+
+// SearchEq matches the documents whose attribute is equal to the value.
+func SearchEq(attribute string, value interface{}) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("%s == %s", b.attribute(attribute), b.value(value))
+	})
+}
+
+// Phrase matches the documents whose attribute contains the phrase.
+// It must be wrapped in Analyzer if the analyzer is not "identity".
+func Phrase(attribute, phrase string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("PHRASE(%s, %s)", b.attribute(attribute), b.value(phrase))
+	})
+}
+
+// Tokens matches the documents whose attribute contains any of the tokens of the text
+// produced by the analyzer.
+func Tokens(attribute, text, analyzer string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("%s IN TOKENS(%s, %s)", b.attribute(attribute), b.value(text), b.value(analyzer))
+	})
+}
+
+// AllTokens matches the documents whose attribute contains all the tokens of the text
+// produced by the analyzer.
+func AllTokens(attribute, text, analyzer string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("TOKENS(%s, %s) ALL == %s", b.value(text), b.value(analyzer), b.attribute(attribute))
+	})
+}
+
+// NgramMatch matches the documents whose attribute is similar to the target,
+// according to the n-grams produced by the analyzer. The threshold is between 0 and 1.
+func NgramMatch(attribute, target string, threshold float64, analyzer string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("NGRAM_MATCH(%s, %s, %s, %s)",
+			b.attribute(attribute), b.value(target), b.value(threshold), b.value(analyzer))
+	})
+}
+
+// LevenshteinMatch matches the documents whose attribute is within the Levenshtein distance of the target.
+// If transpositions is set, the Damerau-Levenshtein distance is used.
+func LevenshteinMatch(attribute, target string, distance int, transpositions bool) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("LEVENSHTEIN_MATCH(%s, %s, %s, %s)",
+			b.attribute(attribute), b.value(target), b.value(distance), b.value(transpositions))
+	})
+}
+
+// StartsWith matches the documents whose attribute starts with the prefix.
+func StartsWith(attribute, prefix string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("STARTS_WITH(%s, %s)", b.attribute(attribute), b.value(prefix))
+	})
+}
+
+// InRange matches the documents whose attribute is between low and high.
+func InRange(attribute string, low, high interface{}, includeLow, includeHigh bool) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("IN_RANGE(%s, %s, %s, %s, %s)",
+			b.attribute(attribute), b.value(low), b.value(high), b.value(includeLow), b.value(includeHigh))
+	})
+}
+
+// Analyzer sets the analyzer used by the expression.
+func Analyzer(expr SearchExpr, analyzer string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("ANALYZER(%s, %s)", expr.build(b), b.value(analyzer))
+	})
+}
+
+// Boost multiplies the score of the documents matched by the expression.
+func Boost(expr SearchExpr, boost float64) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("BOOST(%s, %s)", expr.build(b), b.value(boost))
+	})
+}
+
+// SearchAnd matches the documents matched by all the expressions.
+func SearchAnd(exprs ...SearchExpr) SearchExpr {
+	return joinExprs(exprs, " AND ")
+}
+
+// SearchOr matches the documents matched by any of the expressions.
+func SearchOr(exprs ...SearchExpr) SearchExpr {
+	return joinExprs(exprs, " OR ")
+}
+
+// SearchNot matches the documents not matched by the expression.
+func SearchNot(expr SearchExpr) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		return fmt.Sprintf("NOT (%s)", expr.build(b))
+	})
+}
+
+func joinExprs(exprs []SearchExpr, operator string) SearchExpr {
+	return searchExpr(func(b *searchBinder) string {
+		built := make([]string, len(exprs))
+		for i, expr := range exprs {
+			built[i] = "(" + expr.build(b) + ")"
+		}
+		return strings.Join(built, operator)
+	})
+}
@@ -0,0 +1,70 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestSearch runs tests on the search query builder.
+func TestSearch(t *testing.T) {
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		search *requests.Search
+		// Expected results
+		output string
+	}{
+		{
+			description: "no condition",
+			search:      requests.NewSearch("nodesView"),
+			output:      `{"query":"FOR d IN @@view RETURN d","bindVars":{"@view":"nodesView"}}`,
+		},
+		{
+			description: "phrase sorted by score",
+			search: requests.NewSearch("nodesView").
+				Where(requests.Analyzer(requests.Phrase("description", `"fast" server`), "text_en")).
+				SortBM25().
+				Limit(10, 20),
+			output: `{"query":"FOR d IN @@view SEARCH ANALYZER(PHRASE(d.@search0, @search1), @search2) ` +
+				`SORT BM25(d) DESC LIMIT @search3, @search4 RETURN d",` +
+				`"bindVars":{"@view":"nodesView","search0":["description"],"search1":"\"fast\" server",` +
+				`"search2":"text_en","search3":10,"search4":20}}`,
+		},
+		{
+			description: "combined conditions",
+			search: requests.NewSearch("nodesView").
+				Where(requests.SearchAnd(
+					requests.SearchOr(
+						requests.Boost(requests.StartsWith("address.city", "Par"), 2),
+						requests.LevenshteinMatch("name", "srever", 1, true),
+					),
+					requests.SearchNot(requests.InRange("weight", 0, 10, true, false)),
+					requests.Tokens("tags", "web db", "text_en"),
+					requests.NgramMatch("name", "server", 0.7, "bigram"),
+				)).
+				SortTFIDF(true).
+				Sort("name", false),
+			output: `{"query":"FOR d IN @@view SEARCH ((BOOST(STARTS_WITH(d.@search0, @search1), @search2)) OR ` +
+				`(LEVENSHTEIN_MATCH(d.@search3, @search4, @search5, @search6))) AND ` +
+				`(NOT (IN_RANGE(d.@search7, @search8, @search9, @search10, @search11))) AND ` +
+				`(d.@search12 IN TOKENS(@search13, @search14)) AND ` +
+				`(NGRAM_MATCH(d.@search15, @search16, @search17, @search18)) ` +
+				`SORT TFIDF(d, @search19) DESC, d.@search20 RETURN d",` +
+				`"bindVars":{"@view":"nodesView","search0":["address","city"],"search1":"Par","search10":true,` +
+				`"search11":false,"search12":["tags"],"search13":"web db","search14":"text_en",` +
+				`"search15":["name"],"search16":"server","search17":0.7,"search18":"bigram","search19":true,` +
+				`"search2":2,"search20":["name"],"search3":["name"],"search4":"srever","search5":1,` +
+				`"search6":true,"search7":["weight"],"search8":0,"search9":10}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if output := string(tc.search.AQL().Generate()); output != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, output)
+			}
+		})
+	}
+}
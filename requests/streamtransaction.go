@@ -0,0 +1,95 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionCollections are the collections used by a transaction.
+type TransactionCollections struct {
+	Read      []string `json:"read,omitempty"`
+	Write     []string `json:"write,omitempty"`
+	Exclusive []string `json:"exclusive,omitempty"`
+}
+
+// BeginTransaction begins a stream transaction.
+// The requests of the transaction must then be sent with the
+// "x-arango-trx-id" header set to the returned transaction ID.
+type BeginTransaction struct {
+	Collections        TransactionCollections `json:"collections"`
+	WaitForSync        bool                   `json:"waitForSync,omitempty"`
+	AllowImplicit      *bool                  `json:"allowImplicit,omitempty"`
+	LockTimeout        int                    `json:"lockTimeout,omitempty"`
+	MaxTransactionSize int64                  `json:"maxTransactionSize,omitempty"`
+}
+
+func (r *BeginTransaction) Path() string {
+	return "/_api/transaction/begin"
+}
+
+func (r *BeginTransaction) Method() string {
+	return "POST"
+}
+
+func (r *BeginTransaction) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// CommitTransaction commits a running stream transaction.
+type CommitTransaction struct {
+	ID string
+}
+
+func (r *CommitTransaction) Path() string {
+	return fmt.Sprintf("/_api/transaction/%s", r.ID)
+}
+
+func (r *CommitTransaction) Method() string {
+	return "PUT"
+}
+
+func (r *CommitTransaction) Generate() []byte {
+	return nil
+}
+
+// AbortTransaction aborts a running stream transaction.
+type AbortTransaction struct {
+	ID string
+}
+
+func (r *AbortTransaction) Path() string {
+	return fmt.Sprintf("/_api/transaction/%s", r.ID)
+}
+
+func (r *AbortTransaction) Method() string {
+	return "DELETE"
+}
+
+func (r *AbortTransaction) Generate() []byte {
+	return nil
+}
+
+// GetTransactionStatus retrieves the status of a stream transaction.
+type GetTransactionStatus struct {
+	ID string
+}
+
+func (r *GetTransactionStatus) Path() string {
+	return fmt.Sprintf("/_api/transaction/%s", r.ID)
+}
+
+func (r *GetTransactionStatus) Method() string {
+	return "GET"
+}
+
+func (r *GetTransactionStatus) Generate() []byte {
+	return nil
+}
+
+// TransactionStatus is the status of a stream transaction.
+type TransactionStatus struct {
+	ID string `json:"id"`
+	// One of "running", "committed" or "aborted".
+	Status string `json:"status"`
+}
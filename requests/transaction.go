@@ -3,19 +3,32 @@ package requests
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"regexp"
-	"strings"
+	"sort"
 )
 
 // Transaction represents an ArangoDB transaction.
 type Transaction struct {
-	readCol, writeCol []string
-	resultVars        []string
-	queries           []AQL
-	returnVar         string
-	bindVars          map[string]string
-	lockTimeout       *int
-	waitForSync       *bool
+	readCol, writeCol       []string
+	exclusiveCol            []string
+	steps                   []transactionStep
+	returnVar               string
+	bindVars                map[string]interface{}
+	lockTimeout             *int
+	waitForSync             *bool
+	allowImplicit           *bool
+	maxTransactionSize      int64
+	intermediateCommitCount int
+	intermediateCommitSize  int64
+	aqlMode                 bool
+}
+
+// transactionStep is either an AQL query, whose result is set in resultVar, or raw Javascript.
+type transactionStep struct {
+	resultVar string
+	query     *AQL
+	js        string
 }
 
 // NewTransaction returns a new Transaction object.
@@ -38,13 +51,28 @@ func NewTransaction(readCol, writeCol []string) *Transaction {
 //      AddAQL("var2", "FOR d IN {{.var1}} RETURN d._id").Run(db)
 //
 func (t *Transaction) AddAQL(resultVar, query string, params ...interface{}) *Transaction {
-	t.resultVars = append(t.resultVars, resultVar)
-	t.queries = append(t.queries, *NewAQL(toES6Template(query), params...))
+	t.steps = append(t.steps, transactionStep{resultVar: resultVar, query: NewAQL(query, params...)})
+	return t
+}
+
+// AddJS adds raw Javascript statements to the transaction, executed after the
+// previously added queries. The query result variables, the bind parameters and
+// the "db" object are in scope. Throwing an exception aborts the transaction.
+// Raw Javascript can't be compiled to AQL, so the query fails in AQL mode.
+//
+// e.g. NewTransaction([]string{}, []string{"documents"}).
+//      AddAQL("docs", "FOR d IN documents FILTER d.locked RETURN d").
+//      AddJS("if (docs.length > 0) { throw 'locked documents'; }").
+//      AddAQL("", "FOR d IN documents REMOVE d IN documents")
+//
+func (t *Transaction) AddJS(js string) *Transaction {
+	t.steps = append(t.steps, transactionStep{js: js})
 	return t
 }
 
 // Bind sets the name and value of a bind parameter
 // Binding parameters prevents AQL injection
+// The values are sent in the transaction params and never pasted in the Javascript source.
 // Example:
 // transaction := NewTransaction([]string{}, []string{}).
 // 		AddAQL("var1", "FOR d IN nodes FILTER d._key == @key RETURN d._id").
@@ -53,10 +81,9 @@ func (t *Transaction) AddAQL(resultVar, query string, params ...interface{}) *Tr
 //
 func (t *Transaction) Bind(name string, value interface{}) *Transaction {
 	if t.bindVars == nil {
-		t.bindVars = make(map[string]string)
+		t.bindVars = make(map[string]interface{})
 	}
-	m, _ := json.Marshal(value)
-	t.bindVars[name] = strings.Replace(string(m), `"`, "'", -1)
+	t.bindVars[name] = value
 	return t
 }
 
@@ -78,7 +105,54 @@ func (t *Transaction) WaitForSync(waitForSync bool) *Transaction {
 	return t
 }
 
+// Exclusive sets the collections exclusively locked by the transaction.
+func (t *Transaction) Exclusive(exclusiveCol []string) *Transaction {
+	t.exclusiveCol = exclusiveCol
+	return t
+}
+
+// AllowImplicit sets the optional allowImplicit flag, allowing the transaction
+// to read from undeclared collections.
+func (t *Transaction) AllowImplicit(allowImplicit bool) *Transaction {
+	t.allowImplicit = &allowImplicit
+	return t
+}
+
+// MaxTransactionSize sets the optional maximum transaction size, in bytes.
+func (t *Transaction) MaxTransactionSize(maxTransactionSize int64) *Transaction {
+	t.maxTransactionSize = maxTransactionSize
+	return t
+}
+
+// IntermediateCommitCount sets the optional number of operations after which
+// an intermediate commit is performed automatically.
+func (t *Transaction) IntermediateCommitCount(intermediateCommitCount int) *Transaction {
+	t.intermediateCommitCount = intermediateCommitCount
+	return t
+}
+
+// IntermediateCommitSize sets the optional total size of operations, in bytes,
+// after which an intermediate commit is performed automatically.
+func (t *Transaction) IntermediateCommitSize(intermediateCommitSize int64) *Transaction {
+	t.intermediateCommitSize = intermediateCommitSize
+	return t
+}
+
+// AQLMode compiles the transaction into a single AQL query sent to the cursor API
+// instead of a Javascript transaction, for servers where Javascript transactions are disabled.
+// Each query result is assigned to an AQL variable using a "LET resultVar = (query)" block,
+// so the "{{.resultVar}}" references become AQL variables. The bind parameters are passed unchanged.
+// The collections, lockTimeout and waitForSync options are unused in this mode, and
+// AQL forbids reading a collection after modifying it in the same query.
+func (t *Transaction) AQLMode(enable bool) *Transaction {
+	t.aqlMode = enable
+	return t
+}
+
 func (t *Transaction) Path() string {
+	if t.aqlMode {
+		return "/_api/cursor"
+	}
 	return "/_api/transaction"
 }
 
@@ -87,32 +161,66 @@ func (t *Transaction) Method() string {
 }
 
 func (t *Transaction) Generate() []byte {
+	if t.aqlMode {
+		return t.compileAQL().Generate()
+	}
+
 	type TransactionFmt struct {
 		Collections struct {
-			Read  []string `json:"read"`
-			Write []string `json:"write"`
+			Read      []string `json:"read"`
+			Write     []string `json:"write"`
+			Exclusive []string `json:"exclusive,omitempty"`
 		} `json:"collections"`
-		Action      string `json:"action"`
-		LockTimeout *int   `json:"lockTimeout,omitempty"`
-		WaitForSync *bool  `json:"waitForSync,omitempty"`
+		Action                  string                 `json:"action"`
+		Params                  map[string]interface{} `json:"params,omitempty"`
+		LockTimeout             *int                   `json:"lockTimeout,omitempty"`
+		WaitForSync             *bool                  `json:"waitForSync,omitempty"`
+		AllowImplicit           *bool                  `json:"allowImplicit,omitempty"`
+		MaxTransactionSize      int64                  `json:"maxTransactionSize,omitempty"`
+		IntermediateCommitCount int                    `json:"intermediateCommitCount,omitempty"`
+		IntermediateCommitSize  int64                  `json:"intermediateCommitSize,omitempty"`
 	}
 
-	transactionFmt := &TransactionFmt{LockTimeout: t.lockTimeout, WaitForSync: t.waitForSync}
+	transactionFmt := &TransactionFmt{
+		Params:                  t.bindVars,
+		LockTimeout:             t.lockTimeout,
+		WaitForSync:             t.waitForSync,
+		AllowImplicit:           t.allowImplicit,
+		MaxTransactionSize:      t.maxTransactionSize,
+		IntermediateCommitCount: t.intermediateCommitCount,
+		IntermediateCommitSize:  t.intermediateCommitSize,
+	}
 	transactionFmt.Collections.Read = t.readCol
 	transactionFmt.Collections.Write = t.writeCol
+	transactionFmt.Collections.Exclusive = t.exclusiveCol
 
-	jsFunc := bytes.NewBufferString("function () { var db = require('internal').db; ")
+	jsArgs := ""
+	if len(t.bindVars) > 0 {
+		jsArgs = "params"
+	}
+	jsFunc := bytes.NewBufferString("function (" + jsArgs + ") { var db = require('internal').db; ")
 
-	for name, value := range t.bindVars {
+	// The bind parameters are read from the action argument, sorted for a stable output.
+	names := make([]string, 0, len(t.bindVars))
+	for name := range t.bindVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
 		jsFunc.WriteString("var ")
 		jsFunc.WriteString(name)
-		jsFunc.WriteString(" = ")
-		jsFunc.WriteString(value)
+		jsFunc.WriteString(" = params.")
+		jsFunc.WriteString(name)
 		jsFunc.WriteString("; ")
 	}
 
-	for i, q := range t.queries {
-		writeQuery(jsFunc, q.query, t.resultVars[i])
+	for _, step := range t.steps {
+		if step.query == nil {
+			jsFunc.WriteString(step.js)
+			jsFunc.WriteString(" ")
+			continue
+		}
+		writeQuery(jsFunc, toES6Template(step.query.query), step.resultVar)
 	}
 
 	if len(t.returnVar) > 0 {
@@ -145,6 +253,39 @@ func writeQuery(buff *bytes.Buffer, aql string, resultVarName string) {
 	buff.WriteString("`).toArray(); ")
 }
 
+// compileAQL compiles the transaction queries into a single AQL query.
+func (t *Transaction) compileAQL() *AQL {
+	query := bytes.NewBuffer(nil)
+
+	for i, step := range t.steps {
+		if step.query == nil {
+			// A Runnable can't return an error, so the database reports it instead.
+			return &AQL{query: "RETURN FAIL('raw Javascript can not be compiled to AQL')"}
+		}
+		resultVar := step.resultVar
+		if len(resultVar) == 0 {
+			// The subquery result still needs a variable name.
+			resultVar = fmt.Sprintf("_query%d", i)
+		}
+		query.WriteString("LET ")
+		query.WriteString(resultVar)
+		query.WriteString(" = (")
+		query.WriteString(re.ReplaceAllString(step.query.query, "$1"))
+		query.WriteString(") ")
+	}
+
+	// The result is unwound so it has the same shape as the Javascript transaction result.
+	if len(t.returnVar) > 0 {
+		query.WriteString("FOR _result IN ")
+		query.WriteString(t.returnVar)
+		query.WriteString(" RETURN _result")
+	} else {
+		query.WriteString("FOR _result IN [] RETURN _result")
+	}
+
+	return &AQL{query: query.String(), bindVars: t.bindVars}
+}
+
 var (
 	re     = regexp.MustCompile(`\{\{\.(\w+)\}\}`)
 	bindRe = regexp.MustCompile(`@(\w+)`)
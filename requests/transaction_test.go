@@ -10,6 +10,7 @@ import (
 
 type aqlParams struct {
 	resultVar, query string
+	js               string
 }
 
 // TestTransaction runs tests on the Transaction request.
@@ -61,6 +62,17 @@ func TestTransaction(t *testing.T) {
 			returnVar: "result",
 			output:    "{\"collections\":{\"read\":[],\"write\":[]},\"action\":\"function () { var db = require('internal').db; var documents = db._query(aqlQuery`FOR x IN documents RETURN x`).toArray(); var result = db._query(aqlQuery`FOR x IN ${documents} RETURN x`).toArray(); return result;}\"}",
 		},
+		{
+			description: "raw javascript",
+			readCol:     []string{},
+			writeCol:    []string{},
+			aqls: []aqlParams{
+				{resultVar: "documents", query: "FOR x IN documents RETURN x"},
+				{js: "if (documents.length === 0) { throw 'no documents'; }"},
+				{resultVar: "", query: "FOR x IN documents REMOVE x IN documents"},
+			},
+			output: "{\"collections\":{\"read\":[],\"write\":[]},\"action\":\"function () { var db = require('internal').db; var documents = db._query(aqlQuery`FOR x IN documents RETURN x`).toArray(); if (documents.length === 0) { throw 'no documents'; } db._query(aqlQuery`FOR x IN documents REMOVE x IN documents`).toArray(); }\"}",
+		},
 		{
 			description: "bind variables",
 			readCol:     []string{},
@@ -70,7 +82,22 @@ func TestTransaction(t *testing.T) {
 				{resultVar: "documents", query: `RETURN {name: @city}`},
 			},
 			returnVar: "documents",
-			output:    "{\"collections\":{\"read\":[],\"write\":[]},\"action\":\"function () { var db = require('internal').db; var city = 'Los Angeles'; var documents = db._query(aqlQuery`RETURN {name: ${city}}`).toArray(); return documents; }\"}",
+			output:    "{\"collections\":{\"read\":[],\"write\":[]},\"action\":\"function (params) { var db = require('internal').db; var city = params.city; var documents = db._query(aqlQuery`RETURN {name: ${city}}`).toArray(); return documents; }\",\"params\":{\"city\":\"Los Angeles\"}}",
+		},
+		{
+			description: "bind variables are passed intact",
+			readCol:     []string{},
+			writeCol:    []string{},
+			bind: map[string]interface{}{
+				"name":  `O'Brien \ "}; db._drop('nodes'); {"`,
+				"attrs": map[string]interface{}{"city": "Zürich", "tags": []string{"a", "b"}},
+			},
+			aqls: []aqlParams{
+				{resultVar: "documents", query: `RETURN {name: @name, attrs: @attrs}`},
+			},
+			returnVar: "documents",
+			output: "{\"collections\":{\"read\":[],\"write\":[]},\"action\":\"function (params) { var db = require('internal').db; var attrs = params.attrs; var name = params.name; var documents = db._query(aqlQuery`RETURN {name: ${name}, attrs: ${attrs}}`).toArray(); return documents; }\"," +
+				`"params":{"attrs":{"city":"Zürich","tags":["a","b"]},"name":"O'Brien \\ \"}; db._drop('nodes'); {\""}}`,
 		},
 	}
 
@@ -78,6 +105,10 @@ func TestTransaction(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tr := requests.NewTransaction(tc.readCol, tc.writeCol)
 			for _, aql := range tc.aqls {
+				if aql.js != "" {
+					tr.AddJS(aql.js)
+					continue
+				}
 				tr.AddAQL(aql.resultVar, aql.query)
 			}
 			for name, value := range tc.bind {
@@ -93,3 +124,92 @@ func TestTransaction(t *testing.T) {
 		})
 	}
 }
+
+// TestTransactionAQLMode runs tests on the Transaction request compiled into an AQL query.
+func TestTransactionAQLMode(t *testing.T) {
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		aqls      []aqlParams
+		bind      map[string]interface{}
+		returnVar string
+		// Expected results
+		output string
+	}{
+		{
+			description: "empty transaction",
+			output:      `{"query":"FOR _result IN [] RETURN _result"}`,
+		},
+		{
+			description: "simple query, no return",
+			aqls: []aqlParams{
+				{resultVar: "", query: "FOR x IN documents REMOVE x IN documents"},
+			},
+			output: `{"query":"LET _query0 = (FOR x IN documents REMOVE x IN documents) FOR _result IN [] RETURN _result"}`,
+		},
+		{
+			description: "raw javascript",
+			aqls: []aqlParams{
+				{resultVar: "documents", query: "FOR x IN documents RETURN x"},
+				{js: "if (documents.length === 0) { throw 'no documents'; }"},
+			},
+			output: `{"query":"RETURN FAIL('raw Javascript can not be compiled to AQL')"}`,
+		},
+		{
+			description: "multiple queries with bind variables",
+			aqls: []aqlParams{
+				{resultVar: "documents", query: "FOR x IN documents FILTER x.city == @city RETURN x"},
+				{resultVar: "result", query: "FOR x IN {{.documents}} RETURN x._id"},
+			},
+			bind:      map[string]interface{}{"city": "Los Angeles"},
+			returnVar: "result",
+			output: `{"query":"LET documents = (FOR x IN documents FILTER x.city == @city RETURN x) ` +
+				`LET result = (FOR x IN documents RETURN x._id) FOR _result IN result RETURN _result",` +
+				`"bindVars":{"city":"Los Angeles"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tr := requests.NewTransaction(nil, nil).AQLMode(true)
+			for _, aql := range tc.aqls {
+				if aql.js != "" {
+					tr.AddJS(aql.js)
+					continue
+				}
+				tr.AddAQL(aql.resultVar, aql.query)
+			}
+			for name, value := range tc.bind {
+				tr.Bind(name, value)
+			}
+			if tc.returnVar != "" {
+				tr.Return(tc.returnVar)
+			}
+
+			if tr.Path() != "/_api/cursor" {
+				t.Errorf("unexpected path. Expected /_api/cursor, got %s", tr.Path())
+			}
+			if string(tr.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tr.Generate())
+			}
+		})
+	}
+}
+
+// TestTransactionOptions runs tests on the Transaction request options.
+func TestTransactionOptions(t *testing.T) {
+	tr := requests.NewTransaction([]string{"foo"}, []string{"bar"}).
+		Exclusive([]string{"baz"}).
+		AllowImplicit(false).
+		MaxTransactionSize(1024).
+		IntermediateCommitCount(100).
+		IntermediateCommitSize(512)
+
+	output := `{"collections":{"read":["foo"],"write":["bar"],"exclusive":["baz"]},` +
+		`"action":"function () { var db = require('internal').db; }",` +
+		`"allowImplicit":false,"maxTransactionSize":1024,"intermediateCommitCount":100,"intermediateCommitSize":512}`
+	if string(tr.Generate()) != output {
+		t.Errorf("unexpected output. Expected %s, got %s", output, tr.Generate())
+	}
+}
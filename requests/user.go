@@ -0,0 +1,296 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// AccessLevel is the access level of a user to a database or a collection.
+type AccessLevel string
+
+const (
+	AccessReadWrite AccessLevel = "rw"
+	AccessReadOnly  AccessLevel = "ro"
+	AccessNone      AccessLevel = "none"
+)
+
+// AllDatabases and AllCollections are the wildcards setting the default access level of a user.
+const (
+	AllDatabases   = "*"
+	AllCollections = "*"
+)
+
+// UserInfo describes a user.
+type UserInfo struct {
+	User   string                 `json:"user"`
+	Active bool                   `json:"active"`
+	Extra  map[string]interface{} `json:"extra"`
+}
+
+// CreateUser creates a user. The result is a UserInfo.
+type CreateUser struct {
+	User   string `json:"user"`
+	Passwd string `json:"passwd,omitempty"`
+	// Defaults to true.
+	Active *bool                  `json:"active,omitempty"`
+	Extra  map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (r *CreateUser) Path() string {
+	return "/_api/user"
+}
+
+func (r *CreateUser) Method() string {
+	return "POST"
+}
+
+func (r *CreateUser) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// GetUser retrieves a user. The result is a UserInfo.
+type GetUser struct {
+	User string
+}
+
+func (r *GetUser) Path() string {
+	return fmt.Sprintf("/_api/user/%s", url.PathEscape(r.User))
+}
+
+func (r *GetUser) Method() string {
+	return "GET"
+}
+
+func (r *GetUser) Generate() []byte {
+	return nil
+}
+
+// ListUsers lists the users. The result can be decoded in a []UserInfo.
+type ListUsers struct{}
+
+func (r *ListUsers) Path() string {
+	return "/_api/user"
+}
+
+func (r *ListUsers) Method() string {
+	return "GET"
+}
+
+func (r *ListUsers) Generate() []byte {
+	return nil
+}
+
+// UpdateUser partially updates a user. Only the set fields are changed.
+// The result is a UserInfo.
+type UpdateUser struct {
+	User   string                 `json:"-"`
+	Passwd string                 `json:"passwd,omitempty"`
+	Active *bool                  `json:"active,omitempty"`
+	Extra  map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (r *UpdateUser) Path() string {
+	return fmt.Sprintf("/_api/user/%s", url.PathEscape(r.User))
+}
+
+func (r *UpdateUser) Method() string {
+	return "PATCH"
+}
+
+func (r *UpdateUser) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// ReplaceUser replaces the password, status and extra data of a user. The result is a UserInfo.
+type ReplaceUser struct {
+	User   string `json:"-"`
+	Passwd string `json:"passwd"`
+	// Defaults to true.
+	Active *bool                  `json:"active,omitempty"`
+	Extra  map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (r *ReplaceUser) Path() string {
+	return fmt.Sprintf("/_api/user/%s", url.PathEscape(r.User))
+}
+
+func (r *ReplaceUser) Method() string {
+	return "PUT"
+}
+
+func (r *ReplaceUser) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// DeleteUser deletes a user.
+type DeleteUser struct {
+	User string
+}
+
+func (r *DeleteUser) Path() string {
+	return fmt.Sprintf("/_api/user/%s", url.PathEscape(r.User))
+}
+
+func (r *DeleteUser) Method() string {
+	return "DELETE"
+}
+
+func (r *DeleteUser) Generate() []byte {
+	return nil
+}
+
+// GetDatabaseAccess retrieves the access level of a user to a database. The result is an AccessLevel.
+type GetDatabaseAccess struct {
+	User string
+	// A database name or AllDatabases.
+	Database string
+}
+
+func (r *GetDatabaseAccess) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database/%s", url.PathEscape(r.User), url.PathEscape(r.Database))
+}
+
+func (r *GetDatabaseAccess) Method() string {
+	return "GET"
+}
+
+func (r *GetDatabaseAccess) Generate() []byte {
+	return nil
+}
+
+// GrantDatabaseAccess sets the access level of a user to a database.
+type GrantDatabaseAccess struct {
+	User string `json:"-"`
+	// A database name or AllDatabases.
+	Database string      `json:"-"`
+	Grant    AccessLevel `json:"grant"`
+}
+
+func (r *GrantDatabaseAccess) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database/%s", url.PathEscape(r.User), url.PathEscape(r.Database))
+}
+
+func (r *GrantDatabaseAccess) Method() string {
+	return "PUT"
+}
+
+func (r *GrantDatabaseAccess) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// RevokeDatabaseAccess clears the access level of a user to a database,
+// so the default one of the user applies.
+type RevokeDatabaseAccess struct {
+	User string
+	// A database name or AllDatabases.
+	Database string
+}
+
+func (r *RevokeDatabaseAccess) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database/%s", url.PathEscape(r.User), url.PathEscape(r.Database))
+}
+
+func (r *RevokeDatabaseAccess) Method() string {
+	return "DELETE"
+}
+
+func (r *RevokeDatabaseAccess) Generate() []byte {
+	return nil
+}
+
+// GetCollectionAccess retrieves the access level of a user to a collection. The result is an AccessLevel.
+type GetCollectionAccess struct {
+	User string
+	// A database name or AllDatabases.
+	Database string
+	// A collection name or AllCollections.
+	Collection string
+}
+
+func (r *GetCollectionAccess) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database/%s/%s", url.PathEscape(r.User), url.PathEscape(r.Database), url.PathEscape(r.Collection))
+}
+
+func (r *GetCollectionAccess) Method() string {
+	return "GET"
+}
+
+func (r *GetCollectionAccess) Generate() []byte {
+	return nil
+}
+
+// GrantCollectionAccess sets the access level of a user to a collection.
+type GrantCollectionAccess struct {
+	User string `json:"-"`
+	// A database name or AllDatabases.
+	Database string `json:"-"`
+	// A collection name or AllCollections.
+	Collection string      `json:"-"`
+	Grant      AccessLevel `json:"grant"`
+}
+
+func (r *GrantCollectionAccess) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database/%s/%s", url.PathEscape(r.User), url.PathEscape(r.Database), url.PathEscape(r.Collection))
+}
+
+func (r *GrantCollectionAccess) Method() string {
+	return "PUT"
+}
+
+func (r *GrantCollectionAccess) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// RevokeCollectionAccess clears the access level of a user to a collection,
+// so the access level of the user to the database applies.
+type RevokeCollectionAccess struct {
+	User string
+	// A database name or AllDatabases.
+	Database string
+	// A collection name or AllCollections.
+	Collection string
+}
+
+func (r *RevokeCollectionAccess) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database/%s/%s", url.PathEscape(r.User), url.PathEscape(r.Database), url.PathEscape(r.Collection))
+}
+
+func (r *RevokeCollectionAccess) Method() string {
+	return "DELETE"
+}
+
+func (r *RevokeCollectionAccess) Generate() []byte {
+	return nil
+}
+
+// DatabaseAccess is the access of a user to a database and its collections.
+type DatabaseAccess struct {
+	Permission  AccessLevel            `json:"permission"`
+	Collections map[string]AccessLevel `json:"collections"`
+}
+
+// GetUserDatabases lists the databases accessible by a user.
+// The result can be decoded in a map[string]AccessLevel, or a map[string]DatabaseAccess if Full is set.
+type GetUserDatabases struct {
+	User string
+	// Also return the access levels to the collections.
+	Full bool
+}
+
+func (r *GetUserDatabases) Path() string {
+	return fmt.Sprintf("/_api/user/%s/database?full=%v", url.PathEscape(r.User), r.Full)
+}
+
+func (r *GetUserDatabases) Method() string {
+	return "GET"
+}
+
+func (r *GetUserDatabases) Generate() []byte {
+	return nil
+}
@@ -0,0 +1,123 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestUserRequests runs tests on the user management requests.
+func TestUserRequests(t *testing.T) {
+	active := false
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request interface {
+			Path() string
+			Method() string
+			Generate() []byte
+		}
+		// Expected results
+		path   string
+		method string
+		output string
+	}{
+		{
+			description: "create user",
+			request:     &requests.CreateUser{User: "a b@c/d", Passwd: "secret"},
+			path:        "/_api/user",
+			method:      "POST",
+			output:      `{"user":"a b@c/d","passwd":"secret"}`,
+		},
+		{
+			description: "get user",
+			request:     &requests.GetUser{User: "a b@c/d"},
+			path:        "/_api/user/a%20b@c%2Fd",
+			method:      "GET",
+		},
+		{
+			description: "update user",
+			request:     &requests.UpdateUser{User: "a b@c/d", Active: &active},
+			path:        "/_api/user/a%20b@c%2Fd",
+			method:      "PATCH",
+			output:      `{"active":false}`,
+		},
+		{
+			description: "replace user",
+			request:     &requests.ReplaceUser{User: "a b@c/d", Passwd: "secret", Extra: map[string]interface{}{"team": "ops"}},
+			path:        "/_api/user/a%20b@c%2Fd",
+			method:      "PUT",
+			output:      `{"passwd":"secret","extra":{"team":"ops"}}`,
+		},
+		{
+			description: "delete user",
+			request:     &requests.DeleteUser{User: "a b@c/d"},
+			path:        "/_api/user/a%20b@c%2Fd",
+			method:      "DELETE",
+		},
+		{
+			description: "get database access",
+			request:     &requests.GetDatabaseAccess{User: "a b@c/d", Database: "my db"},
+			path:        "/_api/user/a%20b@c%2Fd/database/my%20db",
+			method:      "GET",
+		},
+		{
+			description: "grant default database access",
+			request:     &requests.GrantDatabaseAccess{User: "a b@c/d", Database: requests.AllDatabases, Grant: requests.AccessReadOnly},
+			path:        "/_api/user/a%20b@c%2Fd/database/%2A",
+			method:      "PUT",
+			output:      `{"grant":"ro"}`,
+		},
+		{
+			description: "revoke database access",
+			request:     &requests.RevokeDatabaseAccess{User: "a b@c/d", Database: "my db"},
+			path:        "/_api/user/a%20b@c%2Fd/database/my%20db",
+			method:      "DELETE",
+		},
+		{
+			description: "get collection access",
+			request:     &requests.GetCollectionAccess{User: "a b@c/d", Database: "my db", Collection: "my col"},
+			path:        "/_api/user/a%20b@c%2Fd/database/my%20db/my%20col",
+			method:      "GET",
+		},
+		{
+			description: "grant collection access",
+			request: &requests.GrantCollectionAccess{
+				User:       "a b@c/d",
+				Database:   "my db",
+				Collection: "my col",
+				Grant:      requests.AccessReadWrite,
+			},
+			path:   "/_api/user/a%20b@c%2Fd/database/my%20db/my%20col",
+			method: "PUT",
+			output: `{"grant":"rw"}`,
+		},
+		{
+			description: "revoke collection access",
+			request:     &requests.RevokeCollectionAccess{User: "a b@c/d", Database: "my db", Collection: "my col"},
+			path:        "/_api/user/a%20b@c%2Fd/database/my%20db/my%20col",
+			method:      "DELETE",
+		},
+		{
+			description: "user databases",
+			request:     &requests.GetUserDatabases{User: "a b@c/d", Full: true},
+			path:        "/_api/user/a%20b@c%2Fd/database?full=true",
+			method:      "GET",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if path := tc.request.Path(); path != tc.path {
+				t.Errorf("unexpected path. Expected %s, got %s", tc.path, path)
+			}
+			if method := tc.request.Method(); method != tc.method {
+				t.Errorf("unexpected method. Expected %s, got %s", tc.method, method)
+			}
+			if output := string(tc.request.Generate()); output != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, output)
+			}
+		})
+	}
+}
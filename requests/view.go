@@ -0,0 +1,308 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ViewInfo describes a view.
+type ViewInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Either "arangosearch" or "search-alias".
+	Type string `json:"type"`
+}
+
+// ListViews lists all views of the current database. The result can be decoded in a []ViewInfo.
+type ListViews struct{}
+
+func (r *ListViews) Path() string {
+	return "/_api/view"
+}
+
+func (r *ListViews) Method() string {
+	return "GET"
+}
+
+func (r *ListViews) Generate() []byte {
+	return nil
+}
+
+// CreateView creates a view of any type with the given raw properties.
+type CreateView struct {
+	Name       string
+	Type       string
+	Properties map[string]interface{}
+}
+
+func (r *CreateView) Path() string {
+	return "/_api/view"
+}
+
+func (r *CreateView) Method() string {
+	return "POST"
+}
+
+func (r *CreateView) Generate() []byte {
+	view := map[string]interface{}{}
+	for k, v := range r.Properties {
+		view[k] = v
+	}
+	view["name"] = r.Name
+	view["type"] = r.Type
+	m, _ := json.Marshal(view)
+	return m
+}
+
+// DropView deletes a view.
+type DropView struct {
+	Name string
+}
+
+func (r *DropView) Path() string {
+	return fmt.Sprintf("/_api/view/%s", r.Name)
+}
+
+func (r *DropView) Method() string {
+	return "DELETE"
+}
+
+func (r *DropView) Generate() []byte {
+	return nil
+}
+
+// ArangoSearchLink is the indexing definition of a collection linked to an arangosearch view,
+// or of one of its fields.
+type ArangoSearchLink struct {
+	// The analyzers applied to the values. Defaults to "identity".
+	Analyzers []string `json:"analyzers,omitempty"`
+	// The analyzer features, e.g. "frequency", "norm", "position" or "offset".
+	Features []string `json:"features,omitempty"`
+	// The definitions of the indexed fields.
+	Fields map[string]*ArangoSearchLink `json:"fields,omitempty"`
+	// The definitions of the indexed nested fields.
+	Nested           map[string]*ArangoSearchLink `json:"nested,omitempty"`
+	IncludeAllFields bool                         `json:"includeAllFields,omitempty"`
+	// Index the position of the values in arrays.
+	TrackListPositions bool `json:"trackListPositions,omitempty"`
+	// Either "none" (default) or "id", to store the values for the exists() function.
+	StoreValues string `json:"storeValues,omitempty"`
+	// Only for the links of collections: create the link without locking the collection.
+	InBackground bool `json:"inBackground,omitempty"`
+}
+
+// StoredValue is a group of fields stored in an arangosearch view, for the queries to read them without the documents.
+type StoredValue struct {
+	Fields []string `json:"fields"`
+	// Either "lz4" (default) or "none".
+	Compression string `json:"compression,omitempty"`
+}
+
+// ArangoSearchProperties are the properties of an arangosearch view.
+type ArangoSearchProperties struct {
+	// The links of the view, by collection name. A nil link removes the collection from the view when updating it.
+	Links       map[string]*ArangoSearchLink `json:"links,omitempty"`
+	PrimarySort []PrimarySortField           `json:"primarySort,omitempty"`
+	// Either "lz4" (default) or "none".
+	PrimarySortCompression    string                 `json:"primarySortCompression,omitempty"`
+	StoredValues              []StoredValue          `json:"storedValues,omitempty"`
+	CleanupIntervalStep       int                    `json:"cleanupIntervalStep,omitempty"`
+	CommitIntervalMsec        int                    `json:"commitIntervalMsec,omitempty"`
+	ConsolidationIntervalMsec int                    `json:"consolidationIntervalMsec,omitempty"`
+	ConsolidationPolicy       map[string]interface{} `json:"consolidationPolicy,omitempty"`
+}
+
+// SearchAliasIndex is an inverted index of a search-alias view.
+type SearchAliasIndex struct {
+	Collection string `json:"collection"`
+	Index      string `json:"index"`
+	// Only when updating a view: either "add" (default) or "del".
+	Operation string `json:"operation,omitempty"`
+}
+
+// ViewProperties is a container for data returned by a GetViewProperties request.
+// The properties of both view types are decoded.
+type ViewProperties struct {
+	ViewInfo
+	ArangoSearchProperties
+	// Set for search-alias views.
+	Indexes []SearchAliasIndex `json:"indexes"`
+}
+
+// CreateArangoSearchView creates an arangosearch view. The result is a ViewProperties.
+type CreateArangoSearchView struct {
+	Name string
+	ArangoSearchProperties
+}
+
+func (r *CreateArangoSearchView) Path() string {
+	return "/_api/view"
+}
+
+func (r *CreateArangoSearchView) Method() string {
+	return "POST"
+}
+
+func (r *CreateArangoSearchView) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+		*ArangoSearchProperties
+	}{r.Name, "arangosearch", &r.ArangoSearchProperties})
+	return m
+}
+
+// CreateSearchAliasView creates a search-alias view. The result is a ViewProperties.
+type CreateSearchAliasView struct {
+	Name    string
+	Indexes []SearchAliasIndex
+}
+
+func (r *CreateSearchAliasView) Path() string {
+	return "/_api/view"
+}
+
+func (r *CreateSearchAliasView) Method() string {
+	return "POST"
+}
+
+func (r *CreateSearchAliasView) Generate() []byte {
+	m, _ := json.Marshal(struct {
+		Name    string             `json:"name"`
+		Type    string             `json:"type"`
+		Indexes []SearchAliasIndex `json:"indexes"`
+	}{r.Name, "search-alias", r.Indexes})
+	return m
+}
+
+// GetView retrieves the description of a view. The result is a ViewInfo.
+type GetView struct {
+	Name string
+}
+
+func (r *GetView) Path() string {
+	return fmt.Sprintf("/_api/view/%s", r.Name)
+}
+
+func (r *GetView) Method() string {
+	return "GET"
+}
+
+func (r *GetView) Generate() []byte {
+	return nil
+}
+
+// GetViewProperties retrieves the properties of a view. The result is a ViewProperties.
+type GetViewProperties struct {
+	Name string
+}
+
+func (r *GetViewProperties) Path() string {
+	return fmt.Sprintf("/_api/view/%s/properties", r.Name)
+}
+
+func (r *GetViewProperties) Method() string {
+	return "GET"
+}
+
+func (r *GetViewProperties) Generate() []byte {
+	return nil
+}
+
+// UpdateArangoSearchView partially updates the properties of an arangosearch view.
+// Only the given links are changed. The result is a ViewProperties.
+type UpdateArangoSearchView struct {
+	Name string
+	ArangoSearchProperties
+}
+
+func (r *UpdateArangoSearchView) Path() string {
+	return fmt.Sprintf("/_api/view/%s/properties", r.Name)
+}
+
+func (r *UpdateArangoSearchView) Method() string {
+	return "PATCH"
+}
+
+func (r *UpdateArangoSearchView) Generate() []byte {
+	m, _ := json.Marshal(&r.ArangoSearchProperties)
+	return m
+}
+
+// ReplaceArangoSearchView replaces the properties of an arangosearch view.
+// The links which are not given are removed. The result is a ViewProperties.
+type ReplaceArangoSearchView struct {
+	Name string
+	ArangoSearchProperties
+}
+
+func (r *ReplaceArangoSearchView) Path() string {
+	return fmt.Sprintf("/_api/view/%s/properties", r.Name)
+}
+
+func (r *ReplaceArangoSearchView) Method() string {
+	return "PUT"
+}
+
+func (r *ReplaceArangoSearchView) Generate() []byte {
+	m, _ := json.Marshal(&r.ArangoSearchProperties)
+	return m
+}
+
+// UpdateSearchAliasView adds or removes indexes of a search-alias view, according to their Operation.
+// The result is a ViewProperties.
+type UpdateSearchAliasView struct {
+	Name    string             `json:"-"`
+	Indexes []SearchAliasIndex `json:"indexes"`
+}
+
+func (r *UpdateSearchAliasView) Path() string {
+	return fmt.Sprintf("/_api/view/%s/properties", r.Name)
+}
+
+func (r *UpdateSearchAliasView) Method() string {
+	return "PATCH"
+}
+
+func (r *UpdateSearchAliasView) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// ReplaceSearchAliasView replaces the indexes of a search-alias view. The result is a ViewProperties.
+type ReplaceSearchAliasView struct {
+	Name    string             `json:"-"`
+	Indexes []SearchAliasIndex `json:"indexes"`
+}
+
+func (r *ReplaceSearchAliasView) Path() string {
+	return fmt.Sprintf("/_api/view/%s/properties", r.Name)
+}
+
+func (r *ReplaceSearchAliasView) Method() string {
+	return "PUT"
+}
+
+func (r *ReplaceSearchAliasView) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
+
+// RenameView renames a view. The result is a ViewInfo.
+type RenameView struct {
+	Name    string `json:"-"`
+	NewName string `json:"name"`
+}
+
+func (r *RenameView) Path() string {
+	return fmt.Sprintf("/_api/view/%s/rename", r.Name)
+}
+
+func (r *RenameView) Method() string {
+	return "PUT"
+}
+
+func (r *RenameView) Generate() []byte {
+	m, _ := json.Marshal(r)
+	return m
+}
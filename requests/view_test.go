@@ -0,0 +1,72 @@
+package requests_test
+
+import (
+	"testing"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestViews runs tests on the view requests.
+func TestViews(t *testing.T) {
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		request interface{ Generate() []byte }
+		// Expected results
+		output string
+	}{
+		{
+			description: "arangosearch view",
+			request: &requests.CreateArangoSearchView{
+				Name: "nodesView",
+				ArangoSearchProperties: requests.ArangoSearchProperties{
+					Links: map[string]*requests.ArangoSearchLink{
+						"nodes": {
+							Fields: map[string]*requests.ArangoSearchLink{
+								"name": {Analyzers: []string{"text_en"}},
+							},
+							StoreValues: "id",
+						},
+					},
+					PrimarySort: []requests.PrimarySortField{{Field: "name", Direction: "asc"}},
+				},
+			},
+			output: `{"name":"nodesView","type":"arangosearch",` +
+				`"links":{"nodes":{"fields":{"name":{"analyzers":["text_en"]}},"storeValues":"id"}},` +
+				`"primarySort":[{"field":"name","direction":"asc"}]}`,
+		},
+		{
+			description: "search-alias view",
+			request: &requests.CreateSearchAliasView{
+				Name:    "nodesView",
+				Indexes: []requests.SearchAliasIndex{{Collection: "nodes", Index: "byName"}},
+			},
+			output: `{"name":"nodesView","type":"search-alias","indexes":[{"collection":"nodes","index":"byName"}]}`,
+		},
+		{
+			description: "link removal",
+			request: &requests.UpdateArangoSearchView{
+				Name:                   "nodesView",
+				ArangoSearchProperties: requests.ArangoSearchProperties{Links: map[string]*requests.ArangoSearchLink{"nodes": nil}},
+			},
+			output: `{"links":{"nodes":null}}`,
+		},
+		{
+			description: "search-alias index removal",
+			request: &requests.UpdateSearchAliasView{
+				Name:    "nodesView",
+				Indexes: []requests.SearchAliasIndex{{Collection: "nodes", Index: "byName", Operation: "del"}},
+			},
+			output: `{"indexes":[{"collection":"nodes","index":"byName","operation":"del"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if string(tc.request.Generate()) != tc.output {
+				t.Errorf("unexpected output. Expected %s, got %s", tc.output, tc.request.Generate())
+			}
+		})
+	}
+}
@@ -0,0 +1,576 @@
+package arangolite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// Schema describes the databases expected by an application, and their content.
+type Schema struct {
+	Databases []DatabaseSchema
+}
+
+// DatabaseSchema describes a database and its collections, graphs and views.
+type DatabaseSchema struct {
+	Name        string
+	Collections []CollectionSchema
+	// The graphs are ensured after the collections, so their edge collections
+	// can be described with their indexes in Collections.
+	Graphs []GraphSchema
+	Views  []ViewSchema
+}
+
+// CollectionSchema describes a collection and its indexes.
+type CollectionSchema struct {
+	Name string
+	// Defaults to requests.DocumentCollection.
+	Type       requests.CollectionType
+	KeyOptions *requests.KeyOptions
+	Indexes    []IndexSchema
+}
+
+// IndexSchema describes an index.
+// Indexes are matched by name when one is given, and by type and fields otherwise.
+type IndexSchema struct {
+	// One of "persistent", "hash", "skiplist", "geo", "fulltext", "ttl", "inverted" or "mdi".
+	Type   string
+	Name   string
+	Fields []string
+	Unique bool
+	Sparse bool
+	// Only used by TTL indexes.
+	ExpireAfter int
+	// Only used by geo indexes.
+	GeoJSON bool
+	// Only used by fulltext indexes.
+	MinLength int
+}
+
+// GraphSchema describes a graph.
+type GraphSchema struct {
+	Name              string
+	EdgeDefinitions   []requests.EdgeDefinition
+	OrphanCollections []string
+}
+
+// ViewSchema describes a view.
+type ViewSchema struct {
+	Name string
+	// Either "arangosearch" or "search-alias".
+	Type string
+	// The raw properties of the view. For existing views, only the linked
+	// collections and their fields, and the search-alias indexes are compared.
+	Properties map[string]interface{}
+}
+
+// The kinds of objects described by a Schema.
+const (
+	SchemaDatabase   = "database"
+	SchemaCollection = "collection"
+	SchemaIndex      = "index"
+	SchemaGraph      = "graph"
+	SchemaView       = "view"
+)
+
+// SchemaObject identifies an object described by a Schema.
+type SchemaObject struct {
+	// One of SchemaDatabase, SchemaCollection, SchemaIndex, SchemaGraph or SchemaView.
+	Kind     string
+	Database string
+	// The object name. Format for indexes: ':collection/:name', or ':collection/[:fields]' for unnamed indexes.
+	Name string
+}
+
+func (o SchemaObject) String() string {
+	if o.Kind == SchemaDatabase {
+		return fmt.Sprintf("%s %s", o.Kind, o.Database)
+	}
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Database, o.Name)
+}
+
+// SchemaDifference is a difference between a Schema and the database that
+// could not be reconciled without destroying data.
+type SchemaDifference struct {
+	SchemaObject
+	Reason string
+}
+
+func (d SchemaDifference) String() string {
+	return fmt.Sprintf("%s: %s", d.SchemaObject, d.Reason)
+}
+
+// SchemaReport reports the changes made by EnsureSchema.
+type SchemaReport struct {
+	// The objects missing in the database that were created.
+	Created []SchemaObject
+	// The existing objects differing from their description. They are left untouched.
+	Differences []SchemaDifference
+}
+
+// EnsureSchema compares the schema with the content of the database server and
+// creates the missing databases, collections, indexes, graphs and views.
+// Existing objects are never modified: their differences with the schema are reported instead.
+// When an error occurs, the report of the changes made so far is also returned.
+func (db *Database) EnsureSchema(ctx context.Context, schema Schema) (*SchemaReport, error) {
+	report := &SchemaReport{}
+
+	for _, dbSchema := range schema.Databases {
+		if err := db.ensureDatabase(ctx, dbSchema, report); err != nil {
+			return report, withMessage(err, fmt.Sprintf("could not ensure the %s database schema", dbSchema.Name))
+		}
+	}
+
+	return report, nil
+}
+
+func (db *Database) ensureDatabase(ctx context.Context, dbSchema DatabaseSchema, report *SchemaReport) error {
+	handle := db.Database(dbSchema.Name)
+
+	err := handle.Run(ctx, nil, &requests.CurrentDatabase{})
+	switch {
+	case IsErrNotFound(err):
+		if err := db.Database("_system").Run(ctx, nil, &requests.CreateDatabase{Name: dbSchema.Name}); err != nil {
+			return err
+		}
+		report.Created = append(report.Created, SchemaObject{Kind: SchemaDatabase, Database: dbSchema.Name})
+	case err != nil:
+		return err
+	}
+
+	if err := handle.ensureCollections(ctx, dbSchema, report); err != nil {
+		return err
+	}
+	if err := handle.ensureGraphs(ctx, dbSchema, report); err != nil {
+		return err
+	}
+	return handle.ensureViews(ctx, dbSchema, report)
+}
+
+func (db *Database) ensureCollections(ctx context.Context, dbSchema DatabaseSchema, report *SchemaReport) error {
+	// The system collections are listed too, as a schema may describe some.
+	existing := []requests.CollectionInfo{}
+	if err := db.Run(ctx, &existing, &requests.ListCollections{IncludeSystem: true}); err != nil {
+		return err
+	}
+	types := map[string]requests.CollectionType{}
+	for _, col := range existing {
+		types[col.Name] = col.Type
+	}
+
+	for _, col := range dbSchema.Collections {
+		colType := col.Type
+		if colType == 0 {
+			colType = requests.DocumentCollection
+		}
+		obj := SchemaObject{Kind: SchemaCollection, Database: dbSchema.Name, Name: col.Name}
+
+		existingType, ok := types[col.Name]
+		switch {
+		case !ok:
+			q := &requests.CreateCollection{
+				Name:       col.Name,
+				Type:       colType,
+				IsSystem:   strings.HasPrefix(col.Name, "_"),
+				KeyOptions: col.KeyOptions,
+			}
+			if err := db.Run(ctx, nil, q); err != nil {
+				return err
+			}
+			report.Created = append(report.Created, obj)
+		case existingType != colType:
+			report.Differences = append(report.Differences, SchemaDifference{
+				SchemaObject: obj,
+				Reason:       fmt.Sprintf("the collection type is %d instead of %d", existingType, colType),
+			})
+		}
+
+		if ok && col.KeyOptions != nil {
+			props := &requests.CollectionProperties{}
+			if err := db.Run(ctx, props, &requests.GetCollectionProperties{Name: col.Name}); err != nil {
+				return err
+			}
+			if reason := diffKeyOptions(props.KeyOptions, col.KeyOptions); reason != "" {
+				report.Differences = append(report.Differences, SchemaDifference{SchemaObject: obj, Reason: reason})
+			}
+		}
+
+		if err := db.ensureIndexes(ctx, dbSchema.Name, col, report); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) ensureIndexes(ctx context.Context, dbName string, col CollectionSchema, report *SchemaReport) error {
+	if len(col.Indexes) == 0 {
+		return nil
+	}
+
+	list := requests.IndexList{}
+	if err := db.Run(ctx, &list, &requests.ListIndexes{CollectionName: col.Name}); err != nil {
+		return err
+	}
+
+	for _, index := range col.Indexes {
+		obj := SchemaObject{Kind: SchemaIndex, Database: dbName, Name: col.Name + "/" + index.Name}
+		if index.Name == "" {
+			obj.Name = fmt.Sprintf("%s/%v", col.Name, index.Fields)
+		}
+
+		existing, found := findIndex(list.Indexes, index)
+		if found {
+			if reason := diffIndex(existing, index); reason != "" {
+				report.Differences = append(report.Differences, SchemaDifference{SchemaObject: obj, Reason: reason})
+			}
+			continue
+		}
+
+		q := createIndexRequest(col.Name, index)
+		if q == nil {
+			report.Differences = append(report.Differences, SchemaDifference{
+				SchemaObject: obj,
+				Reason:       fmt.Sprintf("unsupported index type %q", index.Type),
+			})
+			continue
+		}
+		if err := db.Run(ctx, nil, q); err != nil {
+			return err
+		}
+		report.Created = append(report.Created, obj)
+	}
+
+	return nil
+}
+
+// normalizeIndexType returns the type under which the database reports the index type.
+func normalizeIndexType(indexType string) string {
+	switch indexType {
+	case "hash", "skiplist":
+		return "persistent"
+	case "geo1", "geo2":
+		return "geo"
+	case "zkd":
+		return "mdi"
+	}
+	return indexType
+}
+
+func findIndex(indexes []requests.IndexInfo, index IndexSchema) (requests.IndexInfo, bool) {
+	for _, existing := range indexes {
+		if index.Name != "" {
+			if existing.Name == index.Name {
+				return existing, true
+			}
+			continue
+		}
+		if normalizeIndexType(existing.Type) == normalizeIndexType(index.Type) &&
+			equalStrings(existing.Fields, index.Fields) {
+			return existing, true
+		}
+	}
+	return requests.IndexInfo{}, false
+}
+
+func diffIndex(existing requests.IndexInfo, index IndexSchema) string {
+	var diffs []string
+	if normalizeIndexType(existing.Type) != normalizeIndexType(index.Type) {
+		diffs = append(diffs, fmt.Sprintf("type is %s instead of %s", existing.Type, index.Type))
+	}
+	if !equalStrings(existing.Fields, index.Fields) {
+		diffs = append(diffs, fmt.Sprintf("fields are %v instead of %v", []string(existing.Fields), index.Fields))
+	}
+	if existing.Unique != index.Unique {
+		diffs = append(diffs, fmt.Sprintf("unique is %v instead of %v", existing.Unique, index.Unique))
+	}
+	if existing.Sparse != index.Sparse {
+		diffs = append(diffs, fmt.Sprintf("sparse is %v instead of %v", existing.Sparse, index.Sparse))
+	}
+	if index.Type == "ttl" && existing.ExpireAfter != index.ExpireAfter {
+		diffs = append(diffs, fmt.Sprintf("expireAfter is %d instead of %d", existing.ExpireAfter, index.ExpireAfter))
+	}
+	return strings.Join(diffs, ", ")
+}
+
+// diffKeyOptions describes how the key options of a collection differ from the expected ones.
+// Only the options set in the expected ones are compared.
+func diffKeyOptions(existing, expected *requests.KeyOptions) string {
+	if existing == nil {
+		existing = &requests.KeyOptions{}
+	}
+	var diffs []string
+	if expected.Type != "" && existing.Type != expected.Type {
+		diffs = append(diffs, fmt.Sprintf("the key generator is %s instead of %s", existing.Type, expected.Type))
+	}
+	if expected.AllowUserKeys != nil && (existing.AllowUserKeys == nil || *existing.AllowUserKeys != *expected.AllowUserKeys) {
+		diffs = append(diffs, fmt.Sprintf("allowUserKeys is %v instead of %v", existing.AllowUserKeys != nil && *existing.AllowUserKeys, *expected.AllowUserKeys))
+	}
+	if expected.Increment != 0 && existing.Increment != expected.Increment {
+		diffs = append(diffs, fmt.Sprintf("the key increment is %d instead of %d", existing.Increment, expected.Increment))
+	}
+	if expected.Offset != 0 && existing.Offset != expected.Offset {
+		diffs = append(diffs, fmt.Sprintf("the key offset is %d instead of %d", existing.Offset, expected.Offset))
+	}
+	return strings.Join(diffs, ", ")
+}
+
+// createIndexRequest returns the request creating the given index, or nil if its type is unsupported.
+func createIndexRequest(collectionName string, index IndexSchema) Runnable {
+	opts := requests.IndexOptions{Name: index.Name}
+
+	switch index.Type {
+	case "persistent", "hash", "skiplist":
+		return &requests.CreatePersistentIndex{
+			CollectionName: collectionName,
+			Fields:         index.Fields,
+			Unique:         index.Unique,
+			Sparse:         index.Sparse,
+			IndexOptions:   opts,
+		}
+	case "geo":
+		return &requests.CreateGeoIndex{
+			CollectionName: collectionName,
+			Fields:         index.Fields,
+			GeoJSON:        index.GeoJSON,
+			IndexOptions:   opts,
+		}
+	case "fulltext":
+		return &requests.CreateFulltextIndex{
+			CollectionName: collectionName,
+			Fields:         index.Fields,
+			MinLength:      index.MinLength,
+			IndexOptions:   opts,
+		}
+	case "ttl":
+		return &requests.CreateTTLIndex{
+			CollectionName: collectionName,
+			Fields:         index.Fields,
+			ExpireAfter:    index.ExpireAfter,
+			IndexOptions:   opts,
+		}
+	case "inverted":
+		fields := make([]requests.InvertedIndexField, len(index.Fields))
+		for i, f := range index.Fields {
+			fields[i] = requests.InvertedIndexField{Name: f}
+		}
+		return &requests.CreateInvertedIndex{
+			CollectionName: collectionName,
+			Fields:         fields,
+			IndexOptions:   opts,
+		}
+	case "mdi":
+		return &requests.CreateMDIndex{
+			CollectionName: collectionName,
+			Fields:         index.Fields,
+			Unique:         index.Unique,
+			Sparse:         index.Sparse,
+			IndexOptions:   opts,
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) ensureGraphs(ctx context.Context, dbSchema DatabaseSchema, report *SchemaReport) error {
+	if len(dbSchema.Graphs) == 0 {
+		return nil
+	}
+
+	list := requests.GraphList{}
+	if err := db.Run(ctx, &list, &requests.ListGraphs{}); err != nil {
+		return err
+	}
+	existing := map[string]requests.Graph{}
+	for _, g := range list.Graphs {
+		existing[g.Name] = g
+	}
+
+	for _, graph := range dbSchema.Graphs {
+		obj := SchemaObject{Kind: SchemaGraph, Database: dbSchema.Name, Name: graph.Name}
+
+		g, ok := existing[graph.Name]
+		if !ok {
+			q := &requests.CreateGraph{
+				Name:              graph.Name,
+				EdgeDefinitions:   graph.EdgeDefinitions,
+				OrphanCollections: graph.OrphanCollections,
+			}
+			if err := db.Run(ctx, nil, q); err != nil {
+				return err
+			}
+			report.Created = append(report.Created, obj)
+			continue
+		}
+
+		if reason := diffGraph(g, graph); reason != "" {
+			report.Differences = append(report.Differences, SchemaDifference{SchemaObject: obj, Reason: reason})
+		}
+	}
+
+	return nil
+}
+
+func diffGraph(existing requests.Graph, graph GraphSchema) string {
+	var diffs []string
+
+	definitions := map[string]requests.EdgeDefinition{}
+	for _, def := range existing.EdgeDefinitions {
+		definitions[def.Collection] = def
+	}
+	for _, def := range graph.EdgeDefinitions {
+		existingDef, ok := definitions[def.Collection]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("edge definition %s is missing", def.Collection))
+		case !equalStringSets(existingDef.From, def.From) || !equalStringSets(existingDef.To, def.To):
+			diffs = append(diffs, fmt.Sprintf("edge definition %s differs", def.Collection))
+		}
+		delete(definitions, def.Collection)
+	}
+	for name := range definitions {
+		diffs = append(diffs, fmt.Sprintf("edge definition %s is not described", name))
+	}
+	if !equalStringSets(existing.OrphanCollections, graph.OrphanCollections) {
+		diffs = append(diffs, fmt.Sprintf("orphan collections are %v instead of %v", existing.OrphanCollections, graph.OrphanCollections))
+	}
+
+	sort.Strings(diffs)
+	return strings.Join(diffs, ", ")
+}
+
+func (db *Database) ensureViews(ctx context.Context, dbSchema DatabaseSchema, report *SchemaReport) error {
+	if len(dbSchema.Views) == 0 {
+		return nil
+	}
+
+	list := []requests.ViewInfo{}
+	if err := db.Run(ctx, &list, &requests.ListViews{}); err != nil {
+		return err
+	}
+	types := map[string]string{}
+	for _, v := range list {
+		types[v.Name] = v.Type
+	}
+
+	for _, view := range dbSchema.Views {
+		obj := SchemaObject{Kind: SchemaView, Database: dbSchema.Name, Name: view.Name}
+
+		existingType, ok := types[view.Name]
+		switch {
+		case !ok:
+			q := &requests.CreateView{Name: view.Name, Type: view.Type, Properties: view.Properties}
+			if err := db.Run(ctx, nil, q); err != nil {
+				return err
+			}
+			report.Created = append(report.Created, obj)
+		case existingType != view.Type:
+			report.Differences = append(report.Differences, SchemaDifference{
+				SchemaObject: obj,
+				Reason:       fmt.Sprintf("the view type is %s instead of %s", existingType, view.Type),
+			})
+		case len(view.Properties) > 0:
+			expected := requests.ViewProperties{}
+			if err := remarshal(view.Properties, &expected); err != nil {
+				return withMessage(err, fmt.Sprintf("invalid properties for the %s view", view.Name))
+			}
+			props := requests.ViewProperties{}
+			if err := db.Run(ctx, &props, &requests.GetViewProperties{Name: view.Name}); err != nil {
+				return err
+			}
+			if reason := diffView(props, expected); reason != "" {
+				report.Differences = append(report.Differences, SchemaDifference{SchemaObject: obj, Reason: reason})
+			}
+		}
+	}
+
+	return nil
+}
+
+// remarshal decodes the JSON encoding of src into dst.
+func remarshal(src, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// diffView describes how the links and indexes of a view differ from the expected ones.
+// Only the linked collections and their top-level fields are compared, and only when described.
+func diffView(existing, expected requests.ViewProperties) string {
+	var diffs []string
+
+	if expected.Links != nil {
+		existingCols, expectedCols := linkNames(existing.Links), linkNames(expected.Links)
+		if !equalStringSets(existingCols, expectedCols) {
+			diffs = append(diffs, fmt.Sprintf("the linked collections are %v instead of %v", existingCols, expectedCols))
+		}
+		for _, col := range expectedCols {
+			existingLink, expectedLink := existing.Links[col], expected.Links[col]
+			if existingLink == nil || expectedLink == nil {
+				continue
+			}
+			existingFields, expectedFields := linkNames(existingLink.Fields), linkNames(expectedLink.Fields)
+			if !equalStringSets(existingFields, expectedFields) {
+				diffs = append(diffs, fmt.Sprintf("the fields of the %s link are %v instead of %v", col, existingFields, expectedFields))
+			}
+			if existingLink.IncludeAllFields != expectedLink.IncludeAllFields {
+				diffs = append(diffs, fmt.Sprintf("includeAllFields of the %s link is %v instead of %v", col, existingLink.IncludeAllFields, expectedLink.IncludeAllFields))
+			}
+		}
+	}
+
+	if expected.Indexes != nil {
+		existingIndexes, expectedIndexes := aliasIndexNames(existing.Indexes), aliasIndexNames(expected.Indexes)
+		if !equalStringSets(existingIndexes, expectedIndexes) {
+			diffs = append(diffs, fmt.Sprintf("the indexes are %v instead of %v", existingIndexes, expectedIndexes))
+		}
+	}
+
+	return strings.Join(diffs, ", ")
+}
+
+// linkNames returns the sorted names of the given links.
+func linkNames(links map[string]*requests.ArangoSearchLink) []string {
+	names := make([]string, 0, len(links))
+	for name := range links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// aliasIndexNames returns the sorted indexes of a search-alias view, formatted as ':collection/:index'.
+func aliasIndexNames(indexes []requests.SearchAliasIndex) []string {
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		names = append(names, index.Collection+"/"+index.Index)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStringSets(a, b []string) bool {
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return equalStrings(a, b)
+}
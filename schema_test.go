@@ -0,0 +1,136 @@
+package arangolite_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestEnsureSchema runs tests on the database EnsureSchema method.
+func TestEnsureSchema(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		route := r.Method + " " + r.URL.Path
+		switch {
+		case route == "GET /_db/app/_api/database/current":
+			fmt.Fprintln(w, `{"result": {"name": "app"}}`)
+		case route == "GET /_db/app/_api/collection":
+			if r.URL.Query().Get("excludeSystem") != "false" {
+				fmt.Fprintln(w, `{"result": [{"name": "users", "type": 2}, {"name": "follows", "type": 2}]}`)
+				return
+			}
+			fmt.Fprintln(w, `{"result": [
+				{"name": "users", "type": 2}, {"name": "follows", "type": 2}, {"name": "_jobs", "type": 2, "isSystem": true}
+			]}`)
+		case route == "GET /_db/app/_api/collection/users/properties":
+			fmt.Fprintln(w, `{"name": "users", "keyOptions": {"type": "traditional", "allowUserKeys": true}}`)
+		case route == "GET /_db/app/_api/index" && r.URL.Query().Get("collection") == "users":
+			fmt.Fprintln(w, `{"indexes": [
+				{"id": "users/0", "name": "primary", "type": "primary", "fields": ["_key"], "unique": true},
+				{"id": "users/1", "name": "byEmail", "type": "persistent", "fields": ["email"], "unique": false}
+			]}`)
+		case route == "GET /_db/app/_api/index":
+			fmt.Fprintln(w, `{"indexes": [{"id": "x/0", "name": "primary", "type": "primary", "fields": ["_key"]}]}`)
+		case route == "GET /_db/app/_api/gharial":
+			fmt.Fprintln(w, `{"graphs": []}`)
+		case route == "GET /_db/app/_api/view":
+			fmt.Fprintln(w, `{"result": [{"name": "search", "type": "arangosearch"}, {"name": "products", "type": "arangosearch"}]}`)
+		case route == "GET /_db/app/_api/view/products/properties":
+			fmt.Fprintln(w, `{"name": "products", "type": "arangosearch", "links": {
+				"posts": {"fields": {"title": {}}},
+				"users": {"fields": {"name": {}}}
+			}}`)
+		case strings.HasPrefix(route, "POST /_db/app/"):
+			w.WriteHeader(201)
+			fmt.Fprintln(w, `{}`)
+		default:
+			w.WriteHeader(500)
+			fmt.Fprintf(w, `{"error": true, "errorMessage": "unexpected request %s"}`, route)
+		}
+	})
+
+	schema := arangolite.Schema{
+		Databases: []arangolite.DatabaseSchema{{
+			Name: "app",
+			Collections: []arangolite.CollectionSchema{
+				{
+					Name:       "users",
+					KeyOptions: &requests.KeyOptions{Type: requests.KeyGeneratorUUID},
+					Indexes: []arangolite.IndexSchema{
+						{Type: "persistent", Name: "byEmail", Fields: []string{"email"}, Unique: true},
+						{Type: "ttl", Fields: []string{"expiresAt"}, ExpireAfter: 3600},
+					},
+				},
+				{Name: "follows", Type: requests.EdgeCollection},
+				{Name: "posts"},
+				{Name: "_jobs"},
+			},
+			Graphs: []arangolite.GraphSchema{{
+				Name:            "social",
+				EdgeDefinitions: []requests.EdgeDefinition{{Collection: "follows", From: []string{"users"}, To: []string{"users"}}},
+			}},
+			Views: []arangolite.ViewSchema{
+				{Name: "search", Type: "search-alias"},
+				{
+					Name: "products",
+					Type: "arangosearch",
+					Properties: map[string]interface{}{
+						"links": map[string]interface{}{
+							"posts": map[string]interface{}{
+								"fields": map[string]interface{}{"title": map[string]interface{}{}, "body": map[string]interface{}{}},
+							},
+						},
+					},
+				},
+			},
+		}},
+	}
+
+	db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+	report, err := db.EnsureSchema(context.Background(), schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &arangolite.SchemaReport{
+		Created: []arangolite.SchemaObject{
+			{Kind: arangolite.SchemaIndex, Database: "app", Name: "users/[expiresAt]"},
+			{Kind: arangolite.SchemaCollection, Database: "app", Name: "posts"},
+			{Kind: arangolite.SchemaGraph, Database: "app", Name: "social"},
+		},
+		Differences: []arangolite.SchemaDifference{
+			{
+				SchemaObject: arangolite.SchemaObject{Kind: arangolite.SchemaCollection, Database: "app", Name: "users"},
+				Reason:       "the key generator is traditional instead of uuid",
+			},
+			{
+				SchemaObject: arangolite.SchemaObject{Kind: arangolite.SchemaIndex, Database: "app", Name: "users/byEmail"},
+				Reason:       "unique is false instead of true",
+			},
+			{
+				SchemaObject: arangolite.SchemaObject{Kind: arangolite.SchemaCollection, Database: "app", Name: "follows"},
+				Reason:       "the collection type is 2 instead of 3",
+			},
+			{
+				SchemaObject: arangolite.SchemaObject{Kind: arangolite.SchemaView, Database: "app", Name: "search"},
+				Reason:       "the view type is arangosearch instead of search-alias",
+			},
+			{
+				SchemaObject: arangolite.SchemaObject{Kind: arangolite.SchemaView, Database: "app", Name: "products"},
+				Reason:       "the linked collections are [posts users] instead of [posts], the fields of the posts link are [title] instead of [body title]",
+			},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("unexpected report. Expected %+v, got %+v", expected, report)
+	}
+}
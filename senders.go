@@ -35,8 +35,8 @@ func (s *basicSender) Send(ctx context.Context, cli *http.Client, req *http.Requ
 
 	parsed := parsedResponse{}
 	// Some API calls (such as /_api/aqlfunction) return arrays, so we have to check that
-	// the body is a JSON object before trying to unmarshal.
-	if strings.Contains(res.Header.Get("Content-Type"), "application/json") && raw[0] == '{' {
+	// the body is a JSON object before trying to unmarshal. HEAD requests have no body at all.
+	if strings.Contains(res.Header.Get("Content-Type"), "application/json") && len(raw) > 0 && raw[0] == '{' {
 		if err := json.Unmarshal(raw, &parsed); err != nil {
 			return nil, withMessage(err, "could not decode the json database response")
 		}
@@ -51,8 +51,19 @@ type parsedResponse struct {
 	ErrorMessage string          `json:"errorMessage"`
 	ErrorNum     int             `json:"errorNum"`
 	Result       json.RawMessage `json:"result"`
-	HasMore      bool            `json:"hasMore"`
-	ID           string          `json:"id"`
+	// Only set in the cursor responses.
+	HasMore *bool  `json:"hasMore"`
+	ID      string `json:"id"`
+	Count   int    `json:"count"`
+	Cached  bool   `json:"cached"`
+	// Only the extra attribute of the cursor responses holds query information,
+	// so it is decoded by Extra once the response is known to be one.
+	Extra json.RawMessage `json:"extra"`
+}
+
+// hasMore indicates if a next result page is available.
+func (p parsedResponse) hasMore() bool {
+	return p.HasMore != nil && *p.HasMore
 }
 
 type response struct {
@@ -74,13 +85,33 @@ func (r *response) StatusCode() int {
 }
 
 func (r *response) HasMore() bool {
-	return r.parsed.HasMore
+	return r.parsed.hasMore()
 }
 
 func (r *response) Cursor() string {
 	return r.parsed.ID
 }
 
+func (r *response) Count() int {
+	return r.parsed.Count
+}
+
+func (r *response) Cached() bool {
+	return r.parsed.Cached
+}
+
+func (r *response) Extra() *QueryExtra {
+	// The cursor responses always have a hasMore attribute.
+	if len(r.parsed.Extra) == 0 || len(r.parsed.Result) == 0 || r.parsed.HasMore == nil {
+		return nil
+	}
+	extra := &QueryExtra{}
+	if err := json.Unmarshal(r.parsed.Extra, extra); err != nil {
+		return nil
+	}
+	return extra
+}
+
 func (r *response) Unmarshal(v interface{}) error {
 	if err := json.Unmarshal(r.raw, v); err != nil {
 		return withMessage(err, "response unmarshalling failed")
@@ -0,0 +1,139 @@
+package arangolite
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TxOptions are the options of a stream transaction.
+type TxOptions struct {
+	// The collections read by the transaction.
+	Read []string
+	// The collections written by the transaction.
+	Write []string
+	// The collections exclusively locked by the transaction.
+	Exclusive []string
+	// The time to wait for the collection locks, in seconds.
+	LockTimeout int
+	// The maximum size of the transaction, in bytes.
+	MaxTransactionSize int64
+	// Allow reading from collections not declared in the transaction. Defaults to true.
+	AllowImplicit *bool
+	// Wait until the transaction has been synced to disk on commit.
+	WaitForSync bool
+}
+
+// Tx is a running stream transaction.
+// Every request run through it is executed inside the transaction.
+type Tx struct {
+	db *Database
+	id string
+}
+
+// BeginTx begins a new stream transaction.
+func (db *Database) BeginTx(ctx context.Context, opts TxOptions) (*Tx, error) {
+	q := &requests.BeginTransaction{
+		Collections: requests.TransactionCollections{
+			Read:      opts.Read,
+			Write:     opts.Write,
+			Exclusive: opts.Exclusive,
+		},
+		WaitForSync:        opts.WaitForSync,
+		AllowImplicit:      opts.AllowImplicit,
+		LockTimeout:        opts.LockTimeout,
+		MaxTransactionSize: opts.MaxTransactionSize,
+	}
+
+	status := requests.TransactionStatus{}
+	if err := db.Run(ctx, &status, q); err != nil {
+		return nil, withMessage(err, "could not begin the transaction")
+	}
+
+	// The transaction uses a copy of the database adding the transaction header to every request.
+	txDB := *db
+	txDB.header = http.Header{}
+	for k, v := range db.header {
+		txDB.header[k] = v
+	}
+	txDB.header.Set("x-arango-trx-id", status.ID)
+
+	return &Tx{db: &txDB, id: status.ID}, nil
+}
+
+// WithTx runs the given function inside a new stream transaction.
+// The transaction is committed if the function succeeds, and aborted
+// if it returns an error, panics or if the commit gets no response.
+func (db *Database) WithTx(ctx context.Context, opts TxOptions, fn func(tx *Tx) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.abort()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.abort()
+		return err
+	}
+
+	if err := tx.db.Run(ctx, nil, &requests.CommitTransaction{ID: tx.id}); err != nil {
+		// Without a response of the database, the transaction may still be running
+		// and hold its locks until it times out.
+		if _, ok := GetStatusCode(err); !ok {
+			tx.abort()
+		}
+		return withMessage(err, "could not commit the transaction")
+	}
+	return nil
+}
+
+// ID returns the transaction ID.
+func (tx *Tx) ID() string {
+	return tx.id
+}
+
+// Run runs the Runnable inside the transaction, follows the query cursor if any
+// and unmarshal the result in the given object.
+func (tx *Tx) Run(ctx context.Context, v interface{}, q Runnable) error {
+	return tx.db.Run(ctx, v, q)
+}
+
+// Send runs the Runnable inside the transaction and returns a "raw" Response object.
+func (tx *Tx) Send(ctx context.Context, q Runnable) (Response, error) {
+	return tx.db.Send(ctx, q)
+}
+
+// Query runs the AQL query inside the transaction and returns a Cursor over its results.
+func (tx *Tx) Query(ctx context.Context, q *requests.AQL) (*Cursor, error) {
+	return tx.db.Query(ctx, q)
+}
+
+// Commit commits the transaction.
+func (tx *Tx) Commit(ctx context.Context) error {
+	if err := tx.db.Run(ctx, nil, &requests.CommitTransaction{ID: tx.id}); err != nil {
+		return withMessage(err, "could not commit the transaction")
+	}
+	return nil
+}
+
+// Abort aborts the transaction.
+func (tx *Tx) Abort(ctx context.Context) error {
+	if err := tx.db.Run(ctx, nil, &requests.AbortTransaction{ID: tx.id}); err != nil {
+		return withMessage(err, "could not abort the transaction")
+	}
+	return nil
+}
+
+// abort aborts the transaction regardless of the caller context, which may already be cancelled.
+func (tx *Tx) abort() error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	return tx.Abort(ctx)
+}
@@ -0,0 +1,115 @@
+package arangolite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/solher/arangolite/v2"
+	"github.com/solher/arangolite/v2/requests"
+)
+
+// TestWithTx runs tests on the database WithTx method.
+func TestWithTx(t *testing.T) {
+	client, server := httpMock()
+	defer server.Close()
+
+	var testCases = []struct {
+		// Case description
+		description string
+		// Arguments
+		fn func(tx *arangolite.Tx) error
+		// The connection is closed without response on commit.
+		failCommit bool
+		// Expected results
+		testErr  func(err error) bool
+		requests []string
+	}{
+		{
+			description: "transaction committed",
+			fn: func(tx *arangolite.Tx) error {
+				return tx.Run(context.Background(), nil, requests.NewAQL(""))
+			},
+			testErr:  func(err error) bool { return err == nil },
+			requests: []string{"POST /_api/transaction/begin", "POST /_api/cursor 1234", "PUT /_api/transaction/1234 1234"},
+		},
+		{
+			description: "transaction aborted on error",
+			fn: func(tx *arangolite.Tx) error {
+				tx.Run(context.Background(), nil, requests.NewAQL(""))
+				return errors.New("foobar")
+			},
+			testErr:  func(err error) bool { return err != nil && err.Error() == "foobar" },
+			requests: []string{"POST /_api/transaction/begin", "POST /_api/cursor 1234", "DELETE /_api/transaction/1234 1234"},
+		},
+		{
+			description: "transaction aborted on panic",
+			fn: func(tx *arangolite.Tx) error {
+				panic("foobar")
+			},
+			testErr:  func(err error) bool { return err == nil },
+			requests: []string{"POST /_api/transaction/begin", "DELETE /_api/transaction/1234 1234"},
+		},
+		{
+			description: "transaction aborted on commit failure",
+			fn: func(tx *arangolite.Tx) error {
+				return nil
+			},
+			failCommit: true,
+			testErr:    func(err error) bool { return err != nil },
+			requests:   []string{"POST /_api/transaction/begin", "PUT /_api/transaction/1234 1234", "DELETE /_api/transaction/1234 1234"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				received []string
+			)
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				received = append(received, strings.TrimSpace(fmt.Sprintf(
+					"%s %s %s",
+					r.Method,
+					strings.TrimPrefix(r.URL.Path, "/_db/_system"),
+					r.Header.Get("x-arango-trx-id"),
+				)))
+				mu.Unlock()
+				if tc.failCommit && r.Method == "PUT" {
+					panic(http.ErrAbortHandler)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/begin") {
+					fmt.Fprintln(w, `{"result": {"id": "1234", "status": "running"}}`)
+					return
+				}
+				fmt.Fprintln(w, `{"result": []}`)
+			})
+
+			db := arangolite.NewDatabase(arangolite.OptHTTPClient(client))
+			err := func() (err error) {
+				defer func() {
+					if p := recover(); p != nil && p != "foobar" {
+						err = fmt.Errorf("unexpected panic: %v", p)
+					}
+				}()
+				return db.WithTx(context.Background(), arangolite.TxOptions{Write: []string{"nodes"}}, tc.fn)
+			}()
+			if ok := tc.testErr(err); !ok {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(received, tc.requests) {
+				t.Errorf("unexpected requests. Expected %v, got %v", tc.requests, received)
+			}
+		})
+	}
+}